@@ -0,0 +1,258 @@
+package markdown
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+// defaultGroupsPlaceholder caddy authentication 处理后用户所在的组
+const defaultGroupsPlaceholder = "{http.auth.user.groups}"
+
+// Access 私有笔记的访问控制, 使用 caddy authentication 处理后的用户({http.auth.user.id}).
+// 除了按路径的规则, markdown元数据中的 access: [group] 也会限制该文档的访问.
+type Access struct {
+	// 按路径的规则, 最具体(路径最长)的匹配规则生效
+	Rules []AccessRule `json:"rules,omitempty"`
+	// 用户所在的组, 支持占位符, 多个组用逗号或空格隔开. Default: {http.auth.user.groups}
+	Groups string `json:"groups,omitempty"`
+	// 没有权限时返回的状态码, 403 或 404(不暴露文件是否存在). Default: 404
+	DenyStatus int `json:"deny_status,omitempty"`
+}
+
+// AccessRule 路径的访问规则
+type AccessRule struct {
+	// 路径前缀(例如 /private/)或者 path.Match 模式(例如 /teams/*/notes)
+	Path string `json:"path"`
+	// 允许访问的用户id或者组, * 表示所有登录的用户; 为空时所有人都可以访问
+	Allow []string `json:"allow,omitempty"`
+}
+
+// viewer 当前请求的用户
+type viewer struct {
+	id     string
+	groups []string
+}
+
+// allows 用户是否满足allow列表, 列表为空时所有人都可以访问
+func (v *viewer) allows(allow []string) bool {
+	if len(allow) == 0 {
+		return true
+	}
+	if v.id == "" {
+		return false
+	}
+	for _, a := range allow {
+		if a == "*" || a == v.id {
+			return true
+		}
+		for _, g := range v.groups {
+			if a == g {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// provisionAccess 设置访问控制的默认值
+func (md *Markdown) provisionAccess() error {
+	if md.Access == nil {
+		return nil
+	}
+	if md.Access.Groups == "" {
+		md.Access.Groups = defaultGroupsPlaceholder
+	}
+	switch md.Access.DenyStatus {
+	case 0:
+		md.Access.DenyStatus = http.StatusNotFound
+	case http.StatusForbidden, http.StatusNotFound:
+	default:
+		return fmt.Errorf("access: deny_status must be 403 or 404, got %d", md.Access.DenyStatus)
+	}
+	return nil
+}
+
+// getViewer 返回当前请求的用户, 没有登录时id为空
+func (md *Markdown) getViewer(r *http.Request) *viewer {
+	repl := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
+	groups := defaultGroupsPlaceholder
+	if md.Access != nil {
+		groups = md.Access.Groups
+	}
+	return &viewer{
+		id: repl.ReplaceAll("{http.auth.user.id}", ""),
+		groups: strings.FieldsFunc(repl.ReplaceAll(groups, ""), func(c rune) bool {
+			return c == ',' || unicode.IsSpace(c)
+		}),
+	}
+}
+
+// accessRule 返回路径urlPath最具体的匹配规则, 没有匹配的规则时返回nil
+func (md *Markdown) accessRule(urlPath string) *AccessRule {
+	if md.Access == nil {
+		return nil
+	}
+	var found *AccessRule
+	for i, rule := range md.Access.Rules {
+		if matchAccessPath(rule.Path, urlPath) && (found == nil || len(rule.Path) > len(found.Path)) {
+			found = &md.Access.Rules[i]
+		}
+	}
+	return found
+}
+
+// matchAccessPath 路径是否在规则的路径下: 模式匹配路径本身或者它的上级目录, 前缀匹配整段路径
+func matchAccessPath(pattern, urlPath string) bool {
+	urlPath = path.Clean("/" + urlPath)
+	if strings.ContainsAny(pattern, "*?[") {
+		pattern = strings.TrimSuffix(pattern, "/")
+		for p := urlPath; ; p = path.Dir(p) {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+			if p == "/" {
+				return false
+			}
+		}
+	}
+	prefix := strings.TrimSuffix(path.Clean("/"+pattern), "/")
+	return urlPath == prefix || strings.HasPrefix(urlPath, prefix+"/")
+}
+
+// malformedAccess 元数据无法解析时的access, 任何用户都不满足它(不能因为解析失败而公开文档)
+var malformedAccess = []string{"\x00malformed"}
+
+// metaAccess 元数据中的access, err 为元数据的解析错误, 此时返回 malformedAccess
+func metaAccess(metaData map[string]interface{}, err error) []string {
+	if err != nil {
+		return malformedAccess
+	}
+	return convert.MetaStrings(metaData, "Access", "access")
+}
+
+// restricted 路径urlPath的文档是否需要权限才能访问, access 为文档元数据中的 access
+func (md *Markdown) restricted(urlPath string, access []string) bool {
+	if len(access) > 0 {
+		return true
+	}
+	rule := md.accessRule(urlPath)
+	return rule != nil && len(rule.Allow) > 0
+}
+
+// canView 当前用户是否可以访问路径urlPath(不带版本前缀)的文档, access 为文档元数据中的 access.
+// 目录列表等所有列出文档的地方都需要使用它过滤.
+func (md *Markdown) canView(r *http.Request, urlPath string, access []string) bool {
+	if !md.restricted(urlPath, access) {
+		return true
+	}
+	if len(access) == 1 && access[0] == malformedAccess[0] {
+		return false
+	}
+	v := md.getViewer(r)
+	if rule := md.accessRule(urlPath); rule != nil && !v.allows(rule.Allow) {
+		return false
+	}
+	return v.allows(access)
+}
+
+// sitePath 文件系统中root下的文件name对应的站点路径
+func sitePath(root, name string) string {
+	if name == root {
+		return "/"
+	}
+	if root != "." {
+		name = strings.TrimPrefix(name, root)
+	}
+	return "/" + strings.TrimPrefix(name, "/")
+}
+
+// restrictedTTL 目录下需要权限的文件的缓存时间
+const restrictedTTL = time.Minute
+
+// restrictedEntry 需要权限才能访问的文件或目录
+type restrictedEntry struct {
+	urlPath string
+	access  []string
+}
+
+// restrictedSet 目录下所有需要权限的文件和目录, 和用户无关, 缓存 restrictedTTL
+type restrictedSet struct {
+	expires time.Time
+	entries []restrictedEntry
+	err     error
+}
+
+// restrictedUnder 返回filename(文件或目录)以及目录下需要权限的文件和目录.
+// 遍历目录需要读取每个markdown文件的元数据, 结果按目录缓存, 所有用户共用
+func (md *Markdown) restrictedUnder(fsys fs.FS, root, filename string) ([]restrictedEntry, error) {
+	key := fileKey{fsys: fsys, name: filename}
+	if v, ok := md.restrictedCache.Load(key); ok {
+		if set := v.(*restrictedSet); time.Now().Before(set.expires) {
+			return set.entries, set.err
+		}
+	}
+	set := &restrictedSet{expires: time.Now().Add(restrictedTTL)}
+	set.err = fs.WalkDir(fsys, filename, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return fs.SkipDir
+		}
+		var access []string
+		if !d.IsDir() && isMarkdownFile(name) {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			access = md.getDocMeta(fsys, name, info).access
+		}
+		if urlPath := sitePath(root, name); md.restricted(urlPath, access) {
+			set.entries = append(set.entries, restrictedEntry{urlPath: urlPath, access: access})
+		}
+		return nil
+	})
+	md.restrictedCache.Store(key, set)
+	return set.entries, set.err
+}
+
+// canViewAll 当前用户是否可以访问filename(文件或目录)以及目录下的所有文件.
+// 汇总了目录下所有文件的数据(例如提交统计)只给可以访问全部文件的用户
+func (md *Markdown) canViewAll(r *http.Request, fsys fs.FS, root, filename string) bool {
+	entries, err := md.restrictedUnder(fsys, root, filename)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if !md.canView(r, e.urlPath, e.access) {
+			return false
+		}
+	}
+	return true
+}
+
+// denied 返回没有权限的错误, 并清除后续handler设置的响应头
+func (md *Markdown) denied(w http.ResponseWriter, r *http.Request) error {
+	for _, h := range []string{"Etag", "Last-Modified", "Content-Length", "Content-Type", "Accept-Ranges"} {
+		w.Header().Del(h)
+	}
+	status := http.StatusNotFound
+	if md.Access != nil {
+		status = md.Access.DenyStatus
+	}
+	return caddyhttp.Error(status, fmt.Errorf("access denied: %s", r.URL.Path))
+}
+
+// setPrivate 需要权限的内容不能被共享缓存
+func setPrivate(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", "private, no-cache")
+}
@@ -0,0 +1,162 @@
+package markdown
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+func TestMatchAccessPath(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/private/", "/private/a.md", true},
+		{"/private/", "/private", true},
+		{"/private", "/private-notes/a.md", false},
+		{"/teams/*/notes", "/teams/a/notes/x.md", true},
+		{"/teams/*/notes", "/teams/a/docs/x.md", false},
+		{"/", "/anything.md", true},
+	}
+	for _, tt := range tests {
+		if got := matchAccessPath(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchAccessPath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCanView(t *testing.T) {
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Access = &Access{Rules: []AccessRule{
+			{Path: "/private/", Allow: []string{"*"}},
+			{Path: "/private/team/", Allow: []string{"team"}},
+			{Path: "/private/open/"},
+		}}
+	})
+	tests := []struct {
+		name   string
+		path   string
+		access []string
+		user   string
+		groups []string
+		want   bool
+	}{
+		{"public", "/a.md", nil, "", nil, true},
+		{"login required", "/private/a.md", nil, "", nil, false},
+		{"any user", "/private/a.md", nil, "bob", nil, true},
+		{"group rule", "/private/team/a.md", nil, "bob", []string{"staff"}, false},
+		{"group member", "/private/team/a.md", nil, "ann", []string{"team"}, true},
+		{"more specific open rule", "/private/open/a.md", nil, "", nil, true},
+		{"front matter", "/a.md", []string{"team"}, "", nil, false},
+		{"front matter member", "/a.md", []string{"team"}, "ann", []string{"team"}, true},
+		{"front matter user id", "/a.md", []string{"ann"}, "ann", nil, true},
+		{"rule and front matter", "/private/a.md", []string{"team"}, "bob", nil, false},
+		{"malformed front matter", "/a.md", malformedAccess, "ann", []string{"team"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRequest("", tt.path, tt.user, tt.groups...)
+			if got := md.canView(r, tt.path, tt.access); got != tt.want {
+				t.Errorf("canView = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetaAccess(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"no front matter", "# A\n", nil},
+		{"no access", "---\ntitle: A\n---\n# A\n", nil},
+		{"access", "---\naccess: [team, ann]\n---\n# A\n", []string{"team", "ann"}},
+		// 解析失败时不能丢掉access而公开文档
+		{"malformed", "---\naccess: [team\ntitle: A\n---\n# A\n", malformedAccess},
+		{"malformed without access", "---\ntitle: : A\n---\n# A\n", malformedAccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := metaAccess(convert.ParseFrontMatter([]byte(tt.src)))
+			if len(got) != len(tt.want) {
+				t.Fatalf("metaAccess() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("metaAccess() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCanViewAll(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":      "# Home\n",
+		"docs/a.md":      "# A\n",
+		"team/README.md": "---\naccess: [team]\n---\n# Team\n",
+		"private/b.md":   "# B\n",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Access = &Access{Rules: []AccessRule{{Path: "/private/", Allow: []string{"*"}}}}
+	})
+	fsys := os.DirFS(root)
+
+	tests := []struct {
+		name     string
+		filename string
+		user     string
+		groups   []string
+		want     bool
+	}{
+		{"public dir", "docs", "", nil, true},
+		{"site as anonymous", ".", "", nil, false},
+		{"site as user", ".", "bob", nil, false},
+		{"site as member", ".", "ann", []string{"team"}, true},
+		{"front matter dir", "team", "bob", nil, false},
+		{"rule dir", "private", "bob", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRequest(root, "/", tt.user, tt.groups...)
+			if got := md.canViewAll(r, fsys, ".", tt.filename); got != tt.want {
+				t.Errorf("canViewAll(%q) = %v, want %v", tt.filename, got, tt.want)
+			}
+		})
+	}
+
+	// 遍历的结果被缓存, 同步后重新遍历
+	writeFiles(t, root, map[string]string{"docs/secret.md": "---\naccess: [team]\n---\n# Secret\n"})
+	r := newTestRequest(root, "/", "")
+	if !md.canViewAll(r, fsys, ".", "docs") {
+		t.Errorf("canViewAll(docs) is not cached")
+	}
+	md.invalidate()
+	if md.canViewAll(r, fsys, ".", "docs") {
+		t.Errorf("canViewAll(docs) = true after invalidate")
+	}
+}
+
+func TestGitStatsWithoutRepository(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"docs/a.md": "# A\n"})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.GitIndexDir = "off"
+	})
+	r := newTestRequest(root, "/docs/", "")
+	fsys, err := md.getFS(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, stats := md.getGitStats(r, fsys, root, root+"/docs"); stats != "[]" {
+		t.Errorf("stats = %s, want []", stats)
+	}
+	md.restrictedCache.Range(func(key, _ any) bool {
+		t.Errorf("directory walked without a repository: %v", key)
+		return true
+	})
+}
@@ -0,0 +1,242 @@
+package markdown
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/kingreatwill/caddy-modules/markdown/filesystem"
+	"github.com/kingreatwill/caddy-modules/markdown/git"
+	"go.uber.org/zap"
+)
+
+// activityMonths 热力图显示的月数
+const activityMonths = 12
+
+// errNoRepository 文件系统没有对应的git仓库(例如zip)
+var errNoRepository = errors.New("no git repository")
+
+// activityData 当前目录或文件的提交统计, 用于热力图
+type activityData struct {
+	Start   string         `json:"start"`
+	Path    string         `json:"path"`
+	Head    string         `json:"head,omitempty"`
+	Data    []git.DayStats `json:"data"`
+	Authors []authorStats  `json:"authors,omitempty"`
+	// 索引正在后台更新, 数据可能不是最新的
+	Indexing bool `json:"indexing,omitempty"`
+}
+
+// authorStats 作者的提交总数
+type authorStats struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+// activitySource 文件所在的仓库和引用, 以及文件在仓库中的路径
+type activitySource struct {
+	repo  *gitv.Repository
+	ref   string
+	head  plumbing.Hash // 引用当前指向的提交
+	scope string
+}
+
+// activityKey 提交统计缓存的key
+type activityKey struct {
+	repo     *gitv.Repository
+	ref      string
+	scope    string
+	byAuthor bool
+}
+
+// activityEntry 提交统计的缓存, 索引更新后重新统计
+type activityEntry struct {
+	index *git.Index
+	data  *activityData
+}
+
+// activityStart 热力图的开始日期: 包含当前月在内的最近 activityMonths 个月的第一天
+func activityStart(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month()-(activityMonths-1), 1, 0, 0, 0, 0, now.Location())
+}
+
+// openRepository 打开path所在的仓库, 打开的仓库会被缓存
+func (md *Markdown) openRepository(path string) (*gitv.Repository, error) {
+	if repo, ok := md.repoCache.Load(path); ok {
+		return repo.(*gitv.Repository), nil
+	}
+	repo, err := git.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	md.repoCache.Store(path, repo)
+	return repo, nil
+}
+
+// getActivitySource 返回文件所在的仓库和引用:
+// git文件系统使用其引用, 本地文件系统使用root所在仓库的HEAD
+func (md *Markdown) getActivitySource(fsys fs.FS, root, filename string) (*activitySource, error) {
+	local := filename
+	switch f := fsys.(type) {
+	case filesystem.RepositoryFS:
+		commit, err := f.Commit()
+		if err != nil {
+			return nil, err
+		}
+		return &activitySource{repo: f.Repository(), ref: f.Revision(), head: commit.Hash, scope: f.RepositoryPath(filename)}, nil
+	case filesystem.LocalFS:
+		root, local = f.LocalPath(root), f.LocalPath(filename)
+	default:
+		if fsys != md.unwrapFS(md.fsmap.Default()) {
+			return nil, errNoRepository
+		}
+	}
+
+	repo, err := md.openRepository(root)
+	if errors.Is(err, gitv.ErrRepositoryNotExists) {
+		return nil, errNoRepository
+	}
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	// bare仓库或者不在工作区中时统计整个仓库
+	scope := "."
+	if wt, err := repo.Worktree(); err == nil {
+		base, _ := filepath.Abs(wt.Filesystem.Root())
+		abs, _ := filepath.Abs(local)
+		if rel, err := filepath.Rel(base, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			scope = filepath.ToSlash(rel)
+		}
+	}
+	return &activitySource{repo: repo, ref: "HEAD", head: head.Hash(), scope: scope}, nil
+}
+
+// getActivity 从索引中统计修改了filename(文件或目录)的提交, 出错时返回没有数据的统计.
+// 索引落后时返回旧的数据并在后台更新, 不会等待遍历提交历史.
+func (md *Markdown) getActivity(fsys fs.FS, root, filename, author string, byAuthor bool) (*activityData, error) {
+	start := activityStart(time.Now())
+	empty := &activityData{Start: start.Format("2006-01-02"), Path: ".", Data: []git.DayStats{}}
+	src, err := md.getActivitySource(fsys, root, filename)
+	if err != nil {
+		return empty, err
+	}
+	idx := md.gitIndex(src.repo, src.ref, src.head)
+	if idx == nil {
+		empty.Path, empty.Indexing = src.scope, true
+		return empty, nil
+	}
+
+	data := md.indexActivity(idx, src, start, author, byAuthor)
+	if idx.Head != src.head.String() {
+		// 返回的数据在缓存中共享, 复制后再设置
+		indexing := *data
+		indexing.Indexing = true
+		return &indexing, nil
+	}
+	return data, nil
+}
+
+// indexActivity 从索引中统计提交, 没有按作者过滤的结果缓存到索引更新为止
+func (md *Markdown) indexActivity(idx *git.Index, src *activitySource, start time.Time, author string, byAuthor bool) *activityData {
+	key := activityKey{repo: src.repo, ref: src.ref, scope: src.scope, byAuthor: byAuthor}
+	if v, ok := md.gitMap.Load(key); ok && author == "" {
+		if entry := v.(*activityEntry); entry.index == idx && entry.data.Start == start.Format("2006-01-02") {
+			return entry.data
+		}
+	}
+	stats := idx.Stats(git.StatsOptions{
+		Path:     src.scope,
+		Since:    start,
+		Author:   author,
+		ByAuthor: byAuthor,
+	})
+
+	data := &activityData{Start: start.Format("2006-01-02"), Path: src.scope, Head: idx.Head, Data: stats}
+	if byAuthor {
+		totals := map[string]int64{}
+		for _, day := range stats {
+			for name, n := range day.Authors {
+				totals[name] += n
+			}
+		}
+		for name, n := range totals {
+			data.Authors = append(data.Authors, authorStats{Name: name, Value: n})
+		}
+		sort.Slice(data.Authors, func(i, j int) bool {
+			if data.Authors[i].Value != data.Authors[j].Value {
+				return data.Authors[i].Value > data.Authors[j].Value
+			}
+			return data.Authors[i].Name < data.Authors[j].Name
+		})
+	}
+	// 按作者过滤的参数来自请求, 不缓存, 避免缓存无限增长
+	if author == "" {
+		md.gitMap.Store(key, &activityEntry{index: idx, data: data})
+	}
+	return data
+}
+
+// getGitStats 返回热力图的开始日期和当前目录或文件的每日提交数量(json),
+// 没有git仓库或者当前用户不能访问目录下的所有文件时没有数据
+func (md *Markdown) getGitStats(r *http.Request, fsys fs.FS, root, filename string) (string, string) {
+	start := activityStart(time.Now()).Format("2006-01-02")
+	// 没有仓库时不需要遍历目录检查权限
+	if _, err := md.getActivitySource(fsys, root, filename); errors.Is(err, errNoRepository) {
+		return start, "[]"
+	}
+	if !md.canViewAll(r, fsys, root, filename) {
+		return start, "[]"
+	}
+	data, err := md.getActivity(fsys, root, filename, "", false)
+	if err != nil && !errors.Is(err, errNoRepository) {
+		md.logger.Debug("git stats", zap.String("path", filename), zap.Error(err))
+	}
+	body, err := json.Marshal(data.Data)
+	if err != nil {
+		return data.Start, "[]"
+	}
+	return data.Start, string(body)
+}
+
+// serveActivity 返回当前目录或文件的提交统计(json), 用于热力图. 目录下有当前用户不能访问的文件时拒绝.
+// Query: activity, author=<名称或邮箱> 只统计该作者, authors=1 按作者统计
+func (md *Markdown) serveActivity(w http.ResponseWriter, r *http.Request) error {
+	fsys, err := md.getFS(r)
+	if err != nil {
+		return statusError(err)
+	}
+	root := md.getRoot(r)
+	filename := strings.TrimSuffix(caddyhttp.SanitizedPathJoin(root, r.URL.Path), "/")
+	if _, err := fs.Stat(fsys, filename); err != nil {
+		return statusError(err)
+	}
+	// 文档元数据中的access, 以及目录下没有权限的文件
+	if !md.canViewAll(r, fsys, root, filename) {
+		return md.denied(w, r)
+	}
+
+	query := r.URL.Query()
+	byAuthor, _ := strconv.ParseBool(query.Get("authors"))
+	data, err := md.getActivity(fsys, root, filename, query.Get("author"), byAuthor)
+	if err != nil && !errors.Is(err, errNoRepository) {
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+	return md.writeResponse(w, r, http.StatusOK, body, "application/json; charset=utf-8")
+}
@@ -0,0 +1,35 @@
+package markdown
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestActivityAccess(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":        "# Home",
+		"notes/public.md":  "# Public",
+		"notes/private.md": "---\naccess: [team]\n---\n# Private",
+		"open/a.md":        "# A",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) { md.Access = &Access{} })
+	tests := []struct {
+		target string
+		user   string
+		want   int
+	}{
+		{"/notes/public.md?activity", "", http.StatusOK},
+		{"/notes/private.md?activity&authors=1", "", http.StatusNotFound},
+		{"/notes/?activity", "", http.StatusNotFound},
+		{"/open/?activity", "", http.StatusOK},
+		{"/notes/private.md?activity", "ann", http.StatusOK},
+		{"/notes/?activity", "ann", http.StatusOK},
+	}
+	for _, tt := range tests {
+		rec := serveTest(t, md, root, newTestRequest(root, tt.target, tt.user, "team"))
+		if rec.Code != tt.want {
+			t.Errorf("%s (user %q): status %d, want %d", tt.target, tt.user, rec.Code, tt.want)
+		}
+	}
+}
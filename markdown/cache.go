@@ -0,0 +1,431 @@
+package markdown
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"github.com/kingreatwill/caddy-modules/markdown/template"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+)
+
+// RenderCache 在内存中缓存渲染后的页面(html和json), 同时保存压缩后的版本, 按 Accept-Encoding 直接返回,
+// 不再经过 encode. 内容、包含的文件、模板或导航树变化时重新渲染; 目录列表和git统计等最多延迟ttl.
+type RenderCache struct {
+	// 缓存的最大字节数, 包括压缩后的版本. Default: 64MiB
+	MaxSize int64 `json:"max_size,omitempty"`
+	// 缓存的有效时间. Default: 1m
+	TTL caddy.Duration `json:"ttl,omitempty"`
+	// 保存的压缩格式, 客户端的权重相同时按这个顺序选择. Default: br zstd gzip
+	Encodings []string `json:"encodings,omitempty"`
+}
+
+const (
+	defaultCacheSize = 64 << 20
+	defaultCacheTTL  = time.Minute
+	// 超过这个大小的输出直接流式返回
+	defaultStreamThreshold = 4 << 20
+	// 小于这个大小的内容不压缩
+	minCompressSize = 1024
+)
+
+var defaultEncodings = []string{"br", "zstd", "gzip"}
+
+// compressors 支持的压缩格式, 缓存的内容只压缩一次, 使用较高的压缩级别
+var compressors = map[string]func([]byte) ([]byte, error){
+	"br": func(body []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w := brotli.NewWriterLevel(&buf, 9)
+		if _, err := w.Write(body); err != nil {
+			return nil, err
+		}
+		err := w.Close()
+		return buf.Bytes(), err
+	},
+	"zstd": func(body []byte) ([]byte, error) {
+		return getZstdEncoder().EncodeAll(body, nil), nil
+	},
+	"gzip": func(body []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(body); err != nil {
+			return nil, err
+		}
+		err = w.Close()
+		return buf.Bytes(), err
+	},
+}
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+)
+
+// getZstdEncoder EncodeAll 可以并发调用, 所有请求共用一个encoder
+func getZstdEncoder() *zstd.Encoder {
+	zstdOnce.Do(func() {
+		zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	})
+	return zstdEncoder
+}
+
+// provisionCache 设置渲染缓存和流式输出的默认值
+func (md *Markdown) provisionCache() error {
+	if md.StreamThreshold == 0 {
+		md.StreamThreshold = defaultStreamThreshold
+	}
+	if md.Cache == nil {
+		return nil
+	}
+	if md.Cache.MaxSize == 0 {
+		md.Cache.MaxSize = defaultCacheSize
+	}
+	if md.Cache.TTL == 0 {
+		md.Cache.TTL = caddy.Duration(defaultCacheTTL)
+	}
+	if md.Cache.Encodings == nil {
+		md.Cache.Encodings = append([]string(nil), defaultEncodings...)
+	}
+	for i, enc := range md.Cache.Encodings {
+		enc = strings.ToLower(enc)
+		if _, ok := compressors[enc]; !ok {
+			return fmt.Errorf("cache: unsupported encoding '%s'", enc)
+		}
+		md.Cache.Encodings[i] = enc
+	}
+	md.renderCache = newRenderCache(md.Cache.MaxSize)
+	return nil
+}
+
+// cacheDep 渲染时读取的文件, 修改后缓存失效
+type cacheDep struct {
+	fsys    fs.FS
+	name    string
+	modTime time.Time
+}
+
+// cacheEntry 缓存的页面
+type cacheEntry struct {
+	key         string
+	contentType string
+	body        []byte
+	etag        string
+	expires     time.Time
+	deps        []cacheDep
+	tree        *siteTree // 渲染时的导航树, 重新生成后缓存失效
+	treeKey     treeKey
+	size        int64 // 计入缓存大小的字节数
+
+	mu       sync.Mutex
+	variants map[string][]byte // 压缩后的内容, nil表示压缩后没有变小
+}
+
+// renderCache 按总字节数淘汰最久没有使用的页面
+type renderCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int64
+	maxSize int64
+}
+
+func newRenderCache(maxSize int64) *renderCache {
+	return &renderCache{entries: map[string]*list.Element{}, lru: list.New(), maxSize: maxSize}
+}
+
+// get 返回没有过期的缓存
+func (c *renderCache) get(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	e := el.Value.(*cacheEntry)
+	if time.Now().After(e.expires) {
+		c.removeElement(el)
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	return e
+}
+
+func (c *renderCache) add(e *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e.size > c.maxSize {
+		return
+	}
+	if el, ok := c.entries[e.key]; ok {
+		c.removeElement(el)
+	}
+	c.entries[e.key] = c.lru.PushFront(e)
+	c.size += e.size
+	c.evict()
+}
+
+func (c *renderCache) remove(e *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[e.key]; ok && el.Value == e {
+		c.removeElement(el)
+	}
+}
+
+// grow 压缩后的版本计入缓存大小
+func (c *renderCache) grow(e *cacheEntry, n int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[e.key]; ok && el.Value == e {
+		e.size += n
+		c.size += n
+		c.evict()
+	}
+}
+
+func (c *renderCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*list.Element{}
+	c.lru.Init()
+	c.size = 0
+}
+
+func (c *renderCache) evict() {
+	for c.size > c.maxSize {
+		el := c.lru.Back()
+		if el == nil {
+			return
+		}
+		c.removeElement(el)
+	}
+}
+
+func (c *renderCache) removeElement(el *list.Element) {
+	e := c.lru.Remove(el).(*cacheEntry)
+	delete(c.entries, e.key)
+	c.size -= e.size
+}
+
+// variant 返回压缩后的内容, 第一次请求该格式时压缩; 内容太小或者压缩后没有变小时返回nil
+func (c *renderCache) variant(e *cacheEntry, encoding string) []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if v, ok := e.variants[encoding]; ok {
+		return v
+	}
+	var v []byte
+	if len(e.body) >= minCompressSize {
+		if compressed, err := compressors[encoding](e.body); err == nil && len(compressed) < len(e.body) {
+			v = compressed
+		}
+	}
+	if e.variants == nil {
+		e.variants = map[string][]byte{}
+	}
+	e.variants[encoding] = v
+	c.grow(e, int64(len(v)))
+	return v
+}
+
+// cacheKey 渲染结果取决于markdown内容、请求的路径和格式、语言、版本、用户(目录列表和导航树按权限过滤)以及站点地址
+func (md *Markdown) cacheKey(r *http.Request, inputStr, format string) string {
+	h := sha256.New()
+	write := func(s string) {
+		io.WriteString(h, s)
+		h.Write([]byte{0})
+	}
+	original := r.Context().Value(caddyhttp.OriginalRequestCtxKey).(http.Request)
+	write(format)
+	write(md.siteURL(r))
+	write(original.URL.Path)
+	write(r.URL.Path)
+	write(r.URL.RawQuery)
+	write(languageFromRequest(r))
+	if v := versionFromRequest(r); v != nil {
+		write(v.name)
+	}
+	v := md.getViewer(r)
+	write(v.id)
+	write(strings.Join(v.groups, ","))
+	write(inputStr)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cached 返回仍然有效的缓存, 读取过的文件或者导航树变化时删除
+func (md *Markdown) cached(key string) *cacheEntry {
+	e := md.renderCache.get(key)
+	if e == nil {
+		return nil
+	}
+	for _, dep := range e.deps {
+		info, err := fs.Stat(dep.fsys, dep.name)
+		if err != nil || !info.ModTime().Equal(dep.modTime) {
+			md.renderCache.remove(e)
+			return nil
+		}
+	}
+	if e.tree != nil && md.getSiteTree(e.treeKey.fsys, e.treeKey.root, e.treeKey.lang) != e.tree {
+		md.renderCache.remove(e)
+		return nil
+	}
+	return e
+}
+
+// storeRendered 把渲染结果放入缓存, body会被复制
+func (md *Markdown) storeRendered(r *http.Request, key, contentType string, body []byte, data *convert.TemplateData) *cacheEntry {
+	e := &cacheEntry{
+		key:         key,
+		contentType: contentType,
+		body:        bytes.Clone(body),
+		etag:        etag(body, data.Includes...),
+		expires:     time.Now().Add(time.Duration(md.Cache.TTL)),
+	}
+	e.size = int64(len(e.body))
+	if fsys, err := md.getFS(r); err == nil {
+		for _, inc := range data.Includes {
+			e.deps = append(e.deps, cacheDep{fsys: fsys, name: inc.Name, modTime: inc.ModTime})
+		}
+		if md.Tree != nil || data.CurrentIsFile {
+			e.treeKey = treeKey{fsys: fsys, root: md.getRoot(r), lang: languageFromRequest(r)}
+			e.tree = md.getSiteTree(e.treeKey.fsys, e.treeKey.root, e.treeKey.lang)
+		}
+	}
+	// root下的模板文件
+	if _, ok := template.Templates[md.Template]; !ok && strings.HasPrefix(contentType, "text/html") {
+		if fsys, err := md.getSiteFS(r); err == nil {
+			name := caddyhttp.SanitizedPathJoin(md.getSiteRoot(r), md.Template)
+			if info, err := fs.Stat(fsys, name); err == nil {
+				e.deps = append(e.deps, cacheDep{fsys: fsys, name: name, modTime: info.ModTime()})
+			}
+		}
+	}
+	md.renderCache.add(e)
+	return e
+}
+
+// acceptEncoding 按 Accept-Encoding 的权重选择缓存的压缩格式, 权重相同时按配置的顺序, 不接受任何格式时返回空
+func (md *Markdown) acceptEncoding(r *http.Request) string {
+	accepts := acceptValues(r.Header.Get("Accept-Encoding"))
+	best, bestQ := "", 0.0
+	for _, enc := range md.Cache.Encodings {
+		q, wildcard := -1.0, -1.0
+		for _, accept := range accepts {
+			switch v := strings.ToLower(accept.value); {
+			case v == enc || (enc == "gzip" && v == "x-gzip"):
+				q = accept.q
+			case v == "*":
+				wildcard = accept.q
+			}
+		}
+		if q < 0 {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// writeCached 返回缓存的页面, 客户端支持时返回压缩后的版本; 每种压缩格式的ETag不同
+func (md *Markdown) writeCached(w http.ResponseWriter, r *http.Request, e *cacheEntry) error {
+	header := w.Header()
+	dynamicHeaders(header)
+	header.Add("Vary", "Accept-Encoding")
+	body, tag := e.body, e.etag
+	if enc := md.acceptEncoding(r); enc != "" {
+		if v := md.renderCache.variant(e, enc); v != nil {
+			body = v
+			tag = strings.TrimSuffix(tag, `"`) + "-" + enc + `"`
+			header.Set("Content-Encoding", enc)
+		}
+	}
+	header.Set("Etag", tag)
+	if etagMatch(r, tag) {
+		header.Del("Content-Type")
+		header.Del("Content-Length")
+		header.Del("Content-Encoding")
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	header.Set("Content-Type", e.contentType)
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(body)
+	return err
+}
+
+// writeRendered 把exec生成的内容写入响应. 超过stream_threshold时不再缓冲, 直接流式返回(没有ETag, 也不缓存);
+// 否则和 writeResponse 相同, key不为空时放入渲染缓存
+func (md *Markdown) writeRendered(w http.ResponseWriter, r *http.Request, status int, contentType, key string, data *convert.TemplateData, exec func(io.Writer) error) error {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	sw := &spillWriter{w: w, status: status, contentType: contentType, limit: md.StreamThreshold, buf: getBuffer()}
+	defer putBuffer(sw.buf)
+	if err := exec(sw); err != nil {
+		if sw.streaming {
+			// 已经发送了响应头, 只能中断输出
+			md.logger.Error("render error", zap.String("path", r.URL.Path), zap.Error(err))
+			return nil
+		}
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+	if sw.streaming {
+		return nil
+	}
+	if key != "" && status == http.StatusOK && md.renderCache != nil {
+		return md.writeCached(w, r, md.storeRendered(r, key, contentType, sw.buf.Bytes(), data))
+	}
+	return md.writeResponse(w, r, status, sw.buf.Bytes(), contentType, data.Includes...)
+}
+
+// spillWriter 内容小于limit时缓冲, 超过后发送响应头并直接写入ResponseWriter; limit小于0时总是缓冲
+type spillWriter struct {
+	w           http.ResponseWriter
+	status      int
+	contentType string
+	limit       int64
+	buf         *bytes.Buffer
+	streaming   bool
+}
+
+func (s *spillWriter) Write(p []byte) (int, error) {
+	if s.streaming {
+		return s.w.Write(p)
+	}
+	if s.limit < 0 || int64(s.buf.Len()+len(p)) <= s.limit {
+		return s.buf.Write(p)
+	}
+	s.streaming = true
+	header := s.w.Header()
+	dynamicHeaders(header)
+	header.Del("Etag")
+	header.Del("Content-Length")
+	header.Set("Content-Type", s.contentType)
+	s.w.WriteHeader(s.status)
+	if _, err := s.w.Write(s.buf.Bytes()); err != nil {
+		return 0, err
+	}
+	s.buf.Reset()
+	return s.w.Write(p)
+}
@@ -0,0 +1,241 @@
+package markdown
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderCacheEviction(t *testing.T) {
+	c := newRenderCache(100)
+	add := func(key string, size int64) *cacheEntry {
+		e := &cacheEntry{key: key, size: size, expires: time.Now().Add(time.Minute)}
+		c.add(e)
+		return e
+	}
+	add("a", 40)
+	add("b", 40)
+	// 访问a后b是最久没有使用的
+	if c.get("a") == nil {
+		t.Fatal("a is not cached")
+	}
+	add("c", 40)
+	// 超过最大大小的页面不缓存
+	add("big", 101)
+	// 压缩后的版本计入大小
+	c.grow(c.get("c"), 30)
+
+	tests := []struct {
+		key    string
+		cached bool
+	}{
+		{"a", false},
+		{"b", false},
+		{"c", true},
+		{"big", false},
+	}
+	for _, tt := range tests {
+		if got := c.get(tt.key) != nil; got != tt.cached {
+			t.Errorf("%s: cached = %v, want %v", tt.key, got, tt.cached)
+		}
+	}
+	if c.size != 70 {
+		t.Errorf("size = %d, want 70", c.size)
+	}
+}
+
+func TestRenderCacheExpires(t *testing.T) {
+	c := newRenderCache(100)
+	c.add(&cacheEntry{key: "old", size: 10, expires: time.Now().Add(-time.Second)})
+	c.add(&cacheEntry{key: "new", size: 10, expires: time.Now().Add(time.Minute)})
+	if c.get("old") != nil {
+		t.Error("expired entry is returned")
+	}
+	if c.get("new") == nil {
+		t.Error("entry is not cached")
+	}
+	if c.size != 10 || len(c.entries) != 1 {
+		t.Errorf("size = %d, entries = %d, want 10, 1", c.size, len(c.entries))
+	}
+}
+
+func TestAcceptEncoding(t *testing.T) {
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Cache = &RenderCache{}
+	})
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"x-gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"gzip, zstd", "zstd"},
+		{"br;q=0.5, gzip;q=0.8", "gzip"},
+		{"br;q=0, gzip", "gzip"},
+		{"*", "br"},
+		{"*;q=0.5, gzip", "gzip"},
+		{"*, br;q=0", "zstd"},
+		{"identity;q=0", ""},
+		{"deflate", ""},
+		{"GZIP", "gzip"},
+	}
+	for _, tt := range tests {
+		r := newTestRequest("", "/", "")
+		r.Header.Set("Accept-Encoding", tt.accept)
+		if got := md.acceptEncoding(r); got != tt.want {
+			t.Errorf("acceptEncoding(%q) = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestServeCached(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md": "# Home\n",
+		"page.md":   "# Page\n\n" + strings.Repeat("cached page text. ", 200) + "\n\n![[part]]\n",
+		"part.md":   "part one\n",
+	})
+	stamp := time.Now().Add(-time.Hour)
+	part := filepath.Join(root, "part.md")
+	if err := os.Chtimes(part, stamp, stamp); err != nil {
+		t.Fatal(err)
+	}
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.Cache = &RenderCache{}
+	})
+	serve := func(encoding, ifNoneMatch string) *http.Response {
+		r := newTestRequest(root, "/page.md", "")
+		r.Header.Set("Accept-Encoding", encoding)
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		return serveTest(t, md, root, r).Result()
+	}
+
+	plain := serve("", "")
+	if plain.StatusCode != http.StatusOK || plain.Header.Get("Content-Encoding") != "" {
+		t.Fatalf("status = %d, Content-Encoding = %q", plain.StatusCode, plain.Header.Get("Content-Encoding"))
+	}
+	gz := serve("gzip", "")
+	tag := plain.Header.Get("Etag")
+	tests := []struct {
+		name, got, want string
+	}{
+		{"Content-Encoding", gz.Header.Get("Content-Encoding"), "gzip"},
+		{"Vary", strings.Join(gz.Header.Values("Vary"), ","), "Accept-Encoding"},
+		{"ETag", gz.Header.Get("Etag"), strings.TrimSuffix(tag, `"`) + `-gzip"`},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(tt.got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+	if res := serve("gzip", gz.Header.Get("Etag")); res.StatusCode != http.StatusNotModified {
+		t.Errorf("If-None-Match: status = %d, want 304", res.StatusCode)
+	}
+	// 未压缩版本的ETag不匹配压缩后的内容
+	if res := serve("gzip", tag); res.StatusCode != http.StatusOK {
+		t.Errorf("If-None-Match with the identity ETag: status = %d, want 200", res.StatusCode)
+	}
+
+	body := func() string {
+		r := newTestRequest(root, "/page.md", "")
+		return serveTest(t, md, root, r).Body.String()
+	}
+	// 包含的文件修改时间没有变化时使用缓存
+	writeFiles(t, root, map[string]string{"part.md": "part two\n"})
+	if err := os.Chtimes(part, stamp, stamp); err != nil {
+		t.Fatal(err)
+	}
+	if got := body(); !strings.Contains(got, "part one") {
+		t.Errorf("page is not cached")
+	}
+	// 包含的文件修改后重新渲染
+	if err := os.Chtimes(part, stamp.Add(time.Minute), stamp.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if got := body(); !strings.Contains(got, "part two") {
+		t.Errorf("page is not rendered again after the include changed")
+	}
+	// 导航树重新生成后重新渲染
+	writeFiles(t, root, map[string]string{"part.md": "part three\n"})
+	if err := os.Chtimes(part, stamp.Add(time.Minute), stamp.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	clearCache(&md.treeCache)
+	if got := body(); !strings.Contains(got, "part three") {
+		t.Errorf("page is not rendered again after the tree changed")
+	}
+}
+
+func TestServeStreamed(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md": "# Home\n",
+		"big.md":    "# Big\n\n" + strings.Repeat("streamed text. ", 500) + "\n",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.Cache = &RenderCache{}
+		md.StreamThreshold = 1024
+	})
+	rec := serveTest(t, md, root, newTestRequest(root, "/big.md", ""))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	if got := rec.Header().Get("Etag"); got != "" {
+		t.Errorf("Etag = %q, want none", got)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/html") {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := strings.Count(rec.Body.String(), "streamed text."); got != 500 {
+		t.Errorf("body has %d of 500 sentences", got)
+	}
+	if n := len(md.renderCache.entries); n != 0 {
+		t.Errorf("%d streamed pages are cached", n)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Cache = &RenderCache{}
+	})
+	withVersion := func(r *http.Request, name string) *http.Request {
+		return r.WithContext(context.WithValue(r.Context(), versionCtxKey, &versionInfo{name: name}))
+	}
+	base := md.cacheKey(newTestRequest("", "/a.md", "ann", "team"), "# A", formatHTML)
+
+	tests := []struct {
+		name   string
+		r      *http.Request
+		input  string
+		format string
+		same   bool
+	}{
+		{"same request", newTestRequest("", "/a.md", "ann", "team"), "# A", formatHTML, true},
+		{"other user", newTestRequest("", "/a.md", "bob", "team"), "# A", formatHTML, false},
+		{"other groups", newTestRequest("", "/a.md", "ann", "staff"), "# A", formatHTML, false},
+		{"anonymous", newTestRequest("", "/a.md", ""), "# A", formatHTML, false},
+		{"version", withVersion(newTestRequest("", "/a.md", "ann", "team"), "v1.0.0"), "# A", formatHTML, false},
+		{"query", newTestRequest("", "/a.md?slides", "ann", "team"), "# A", formatHTML, false},
+		{"format", newTestRequest("", "/a.md", "ann", "team"), "# A", formatJSON, false},
+		{"content", newTestRequest("", "/a.md", "ann", "team"), "# B", formatHTML, false},
+	}
+	for _, tt := range tests {
+		if got := md.cacheKey(tt.r, tt.input, tt.format) == base; got != tt.same {
+			t.Errorf("%s: same key = %v, want %v", tt.name, got, tt.same)
+		}
+	}
+	if a, b := md.cacheKey(withVersion(newTestRequest("", "/a.md", ""), "v1"), "# A", formatHTML),
+		md.cacheKey(withVersion(newTestRequest("", "/a.md", ""), "v2"), "# A", formatHTML); a == b {
+		t.Error("versions share a key")
+	}
+}
@@ -0,0 +1,188 @@
+package convert
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// admonitionMarker 引用第一行的 [!TYPE]、[!type]- Title 或者 [!type]+ Title
+var admonitionMarker = regexp.MustCompile(`^\[!([A-Za-z][\w-]*)\]([+-]?)(?:[ \t]+(.*))?$`)
+
+// admonitionTypes GitHub 和 Obsidian 的提示类型, 别名对应到相同的类型
+var admonitionTypes = map[string]string{
+	"note":      "note",
+	"tip":       "tip",
+	"hint":      "tip",
+	"important": "important",
+	"warning":   "warning",
+	"attention": "warning",
+	"caution":   "caution",
+	"abstract":  "abstract",
+	"summary":   "abstract",
+	"tldr":      "abstract",
+	"info":      "info",
+	"todo":      "todo",
+	"success":   "success",
+	"check":     "success",
+	"done":      "success",
+	"question":  "question",
+	"help":      "question",
+	"faq":       "question",
+	"failure":   "failure",
+	"fail":      "failure",
+	"missing":   "failure",
+	"danger":    "danger",
+	"error":     "danger",
+	"bug":       "bug",
+	"example":   "example",
+	"quote":     "quote",
+	"cite":      "quote",
+}
+
+// KindAdmonition 提示(callout)节点
+var KindAdmonition = ast.NewNodeKind("Admonition")
+
+// Admonition 由 > [!TYPE] 引用转换的提示, 子节点为引用的内容
+type Admonition struct {
+	ast.BaseBlock
+	// 类型(别名已转换), 例如 note、warning
+	AdmonitionType string
+	Title          string
+	// 可以折叠([!type]- 默认折叠, [!type]+ 默认展开)
+	Foldable bool
+	Open     bool
+}
+
+func (n *Admonition) Kind() ast.NodeKind {
+	return KindAdmonition
+}
+
+func (n *Admonition) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Type": n.AdmonitionType, "Title": n.Title}, nil)
+}
+
+// admonitionTransformer 把第一行为 [!TYPE] 的引用转换为提示, 不认识的类型保持为引用
+type admonitionTransformer struct{}
+
+func (admonitionTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	var quotes []*ast.Blockquote
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if bq, ok := n.(*ast.Blockquote); ok && entering {
+			quotes = append(quotes, bq)
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, bq := range quotes {
+		para, ok := bq.FirstChild().(*ast.Paragraph)
+		if !ok || para.Lines().Len() == 0 {
+			continue
+		}
+		first := para.Lines().At(0)
+		m := admonitionMarker.FindSubmatch(util.TrimRightSpace(first.Value(source)))
+		if m == nil {
+			continue
+		}
+		typ, ok := admonitionTypes[strings.ToLower(string(m[1]))]
+		if !ok {
+			continue
+		}
+		node := &Admonition{
+			AdmonitionType: typ,
+			Title:          strings.TrimSpace(string(m[3])),
+			Foldable:       len(m[2]) > 0,
+			Open:           string(m[2]) == "+",
+		}
+		if node.Title == "" {
+			node.Title = strings.ToUpper(typ[:1]) + typ[1:]
+		}
+
+		// 去掉第一行(标记和标题)
+		for c := para.FirstChild(); c != nil && inlineStart(c) >= 0 && inlineStart(c) < first.Stop; {
+			next := c.NextSibling()
+			para.RemoveChild(para, c)
+			c = next
+		}
+		if !para.HasChildren() {
+			bq.RemoveChild(bq, para)
+		}
+		for c := bq.FirstChild(); c != nil; {
+			next := c.NextSibling()
+			node.AppendChild(node, c)
+			c = next
+		}
+		bq.Parent().ReplaceChild(bq.Parent(), bq, node)
+	}
+}
+
+// inlineStart 返回行内节点在源文件中的开始位置
+func inlineStart(n ast.Node) int {
+	switch t := n.(type) {
+	case *ast.Text:
+		return t.Segment.Start
+	case *ast.RawHTML:
+		if t.Segments.Len() > 0 {
+			return t.Segments.At(0).Start
+		}
+	}
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if start := inlineStart(c); start >= 0 {
+			return start
+		}
+	}
+	return -1
+}
+
+// admonitionRenderer 渲染提示: 可以折叠时使用 <details>
+type admonitionRenderer struct{}
+
+func (r admonitionRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindAdmonition, r.render)
+}
+
+func (r admonitionRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*Admonition)
+	tag := "div"
+	if n.Foldable {
+		tag = "details"
+	}
+	if !entering {
+		_, _ = w.WriteString("</" + tag + ">\n")
+		return ast.WalkContinue, nil
+	}
+	_, _ = w.WriteString(`<` + tag + ` class="callout callout-` + n.AdmonitionType + `" data-callout="` + n.AdmonitionType + `"`)
+	if n.Open {
+		_, _ = w.WriteString(" open")
+	}
+	_, _ = w.WriteString(">\n")
+	titleTag := "p"
+	if n.Foldable {
+		titleTag = "summary"
+	}
+	_, _ = w.WriteString(`<` + titleTag + ` class="callout-title">`)
+	_, _ = w.Write(util.EscapeHTML([]byte(n.Title)))
+	_, _ = w.WriteString("</" + titleTag + ">\n")
+	return ast.WalkContinue, nil
+}
+
+// admonitionExtension GitHub(> [!NOTE]) 和 Obsidian(> [!tip]- Title) 风格的提示
+type admonitionExtension struct{}
+
+// AdmonitionExtension 把 > [!TYPE] 引用转换为提示(callout)
+var AdmonitionExtension = &admonitionExtension{}
+
+func (e *admonitionExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(admonitionTransformer{}, 10),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(admonitionRenderer{}, 100),
+	))
+}
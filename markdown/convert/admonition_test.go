@@ -0,0 +1,111 @@
+package convert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAdmonition(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+		not  []string
+	}{
+		{
+			"github",
+			"> [!NOTE]\n> Useful **information**.\n",
+			[]string{
+				`<div class="callout callout-note" data-callout="note">`,
+				`<p class="callout-title">Note</p>`,
+				`<p>Useful <strong>information</strong>.</p>`,
+				"</div>",
+			},
+			[]string{"<blockquote>", "[!NOTE]"},
+		},
+		{
+			"obsidian title",
+			"> [!tip] Read this first\n> body\n",
+			[]string{`<div class="callout callout-tip" data-callout="tip">`, `<p class="callout-title">Read this first</p>`, "<p>body</p>"},
+			[]string{"[!tip]"},
+		},
+		{
+			"alias",
+			"> [!faq] Why?\n> because\n",
+			[]string{`data-callout="question"`, `<p class="callout-title">Why?</p>`},
+			nil,
+		},
+		{
+			"folded",
+			"> [!warning]- Spoiler\n> hidden\n",
+			[]string{
+				`<details class="callout callout-warning" data-callout="warning">`,
+				`<summary class="callout-title">Spoiler</summary>`,
+				"<p>hidden</p>",
+				"</details>",
+			},
+			[]string{" open>"},
+		},
+		{
+			"open",
+			"> [!info]+ Details\n> shown\n",
+			[]string{`<details class="callout callout-info" data-callout="info" open>`, `<summary class="callout-title">Details</summary>`},
+			nil,
+		},
+		{
+			"case insensitive",
+			"> [!WaRnInG]\n> careful\n",
+			[]string{`data-callout="warning"`, `<p class="callout-title">Warning</p>`},
+			nil,
+		},
+		{
+			"no body",
+			"> [!IMPORTANT] Only a title\n",
+			[]string{`<div class="callout callout-important" data-callout="important">` + "\n" + `<p class="callout-title">Only a title</p>` + "\n</div>"},
+			[]string{"<p></p>"},
+		},
+		{
+			"unknown type",
+			"> [!custom] Title\n> text\n",
+			[]string{"<blockquote>", "[!custom] Title"},
+			[]string{"callout"},
+		},
+		{
+			"marker not on the first line",
+			"> text\n> [!NOTE]\n",
+			[]string{"<blockquote>"},
+			[]string{"callout"},
+		},
+		{
+			"escaped title",
+			"> [!note] <b>bold</b> & more\n",
+			[]string{`<p class="callout-title">&lt;b&gt;bold&lt;/b&gt; &amp; more</p>`},
+			nil,
+		},
+		{
+			"nested",
+			"> [!note]\n> outer\n> > [!tip]\n> > inner\n",
+			[]string{`data-callout="note"`, `data-callout="tip"`, "<p>inner</p>"},
+			[]string{"<blockquote>"},
+		},
+	}
+	c := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &TemplateData{}
+			if err := c.Convert(tt.src, data); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(data.MdHtml, want) {
+					t.Errorf("%q not found in %q", want, data.MdHtml)
+				}
+			}
+			for _, not := range tt.not {
+				if strings.Contains(data.MdHtml, not) {
+					t.Errorf("%q found in %q", not, data.MdHtml)
+				}
+			}
+		})
+	}
+}
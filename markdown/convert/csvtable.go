@@ -0,0 +1,355 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// DefaultCSVMaxRows 表格默认最多显示的行数(不包括表头)
+const DefaultCSVMaxRows = 1000
+
+// 推测分隔符时尝试的字符和读取的行数
+var csvDelimiters = []rune{',', ';', '\t', '|'}
+
+const sniffLines = 10
+
+// KindCSVTable 由csv/tsv代码块转换的表格节点
+var KindCSVTable = ast.NewNodeKind("CSVTable")
+
+// CSVTable 由 ```csv 或 ```tsv 代码块转换的表格
+type CSVTable struct {
+	ast.BaseBlock
+	Header []string
+	Rows   [][]string
+	// 数字列右对齐, 按数值排序
+	Numeric []bool
+	// 超过行数限制没有显示的行数
+	More int
+}
+
+func (n *CSVTable) Kind() ast.NodeKind {
+	return KindCSVTable
+}
+
+func (n *CSVTable) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Rows": strconv.Itoa(len(n.Rows)), "More": strconv.Itoa(n.More)}, nil)
+}
+
+// csvOptions 代码块信息中的选项: ```csv header delimiter=; more=10
+type csvOptions struct {
+	delimiter rune
+	header    int // 1有表头, -1没有表头, 0自动判断
+	more      int // 文件被截断时没有包含的行数
+}
+
+func parseCSVInfo(lang string, fields []string) csvOptions {
+	var opts csvOptions
+	if lang == "tsv" {
+		opts.delimiter = '\t'
+	}
+	for _, f := range fields {
+		key, value, _ := strings.Cut(f, "=")
+		switch key {
+		case "header":
+			opts.header = 1
+		case "noheader":
+			opts.header = -1
+		case "delimiter":
+			if d, ok := delimiterNames[value]; ok {
+				opts.delimiter = d
+			} else if r := []rune(value); len(r) == 1 {
+				opts.delimiter = r[0]
+			}
+		case "more":
+			opts.more, _ = strconv.Atoi(value)
+		}
+	}
+	return opts
+}
+
+// delimiterNames 代码块信息中分隔符的名称
+var delimiterNames = map[string]rune{"tab": '\t', "comma": ',', "semicolon": ';', "pipe": '|', `\t`: '\t'}
+
+// SniffDelimiter 按前几行推测分隔符: 每行的列数相同且最多的字符, 无法判断时为逗号
+func SniffDelimiter(sample []byte) rune {
+	best, bestCols := ',', 1
+	for _, d := range csvDelimiters {
+		r := newCSVReader(bytes.NewReader(sample), d)
+		cols, lines := 0, 0
+		for lines < sniffLines {
+			record, err := r.Read()
+			if err != nil {
+				// 样本最后一行可能不完整
+				if !errors.Is(err, io.EOF) && lines < 2 {
+					cols = 0
+				}
+				break
+			}
+			if lines == 0 {
+				cols = len(record)
+			} else if len(record) != cols {
+				cols = 0
+				break
+			}
+			lines++
+		}
+		if cols > bestCols {
+			best, bestCols = d, cols
+		}
+	}
+	return best
+}
+
+func newCSVReader(r io.Reader, delimiter rune) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.Comma = delimiter
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+	return cr
+}
+
+// CSVMarkdown 把csv/tsv文件转换为 ```csv 代码块, 只保留maxRows行(不包括表头), 其余的行只计数
+func CSVMarkdown(content []byte, tsv bool, maxRows int) (string, error) {
+	if maxRows <= 0 {
+		maxRows = DefaultCSVMaxRows
+	}
+	delimiter := '\t'
+	if !tsv {
+		delimiter = SniffDelimiter(head(content, sniffLines))
+	}
+	r := newCSVReader(bytes.NewReader(content), delimiter)
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = delimiter
+	more := 0
+	for n := 0; ; n++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		// 可能有表头, 多保留一行
+		if n > maxRows {
+			more++
+			continue
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	body := buf.String()
+	fence := strings.Repeat("`", max(3, longestRun(body, '`')+1))
+	info := "csv delimiter=" + delimiterName(delimiter)
+	if more > 0 {
+		info += " more=" + strconv.Itoa(more)
+	}
+	return fence + info + "\n" + body + fence + "\n", nil
+}
+
+func delimiterName(d rune) string {
+	for name, r := range delimiterNames {
+		if r == d && name != `\t` {
+			return name
+		}
+	}
+	return string(d)
+}
+
+// head 返回前n行
+func head(content []byte, n int) []byte {
+	end := 0
+	for i := 0; i < n && end < len(content); i++ {
+		next := bytes.IndexByte(content[end:], '\n')
+		if next < 0 {
+			return content
+		}
+		end += next + 1
+	}
+	return content[:end]
+}
+
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// isNumeric 数字、千分位和百分比, 例如 -1.5 1,234 12%
+func isNumeric(s string) bool {
+	s = strings.TrimSuffix(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), "%")
+	if s == "" {
+		return false
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// numericColumns 所有非空的值都是数字的列
+func numericColumns(rows [][]string, cols int) []bool {
+	numeric := make([]bool, cols)
+	for c := range numeric {
+		seen := false
+		numeric[c] = true
+		for _, row := range rows {
+			if c >= len(row) || strings.TrimSpace(row[c]) == "" {
+				continue
+			}
+			seen = true
+			if !isNumeric(row[c]) {
+				numeric[c] = false
+				break
+			}
+		}
+		numeric[c] = numeric[c] && seen
+	}
+	return numeric
+}
+
+// hasHeader 判断第一行是否可能是表头: 没有空值和数字, 并且不重复
+func hasHeader(records [][]string) bool {
+	if len(records) < 2 {
+		return false
+	}
+	seen := map[string]bool{}
+	for _, cell := range records[0] {
+		cell = strings.TrimSpace(cell)
+		if cell == "" || isNumeric(cell) || seen[cell] {
+			return false
+		}
+		seen[cell] = true
+	}
+	return true
+}
+
+// csvTransformer 把语言为csv或tsv的代码块转换为表格, 无法解析时保持为代码块
+type csvTransformer struct {
+	maxRows int
+}
+
+func (t csvTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	var blocks []*ast.FencedCodeBlock
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if b, ok := n.(*ast.FencedCodeBlock); ok && entering {
+			if lang := strings.ToLower(string(b.Language(source))); lang == "csv" || lang == "tsv" {
+				blocks = append(blocks, b)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, b := range blocks {
+		if table := t.table(b, source); table != nil {
+			b.Parent().ReplaceChild(b.Parent(), b, table)
+		}
+	}
+}
+
+func (t csvTransformer) table(b *ast.FencedCodeBlock, source []byte) *CSVTable {
+	var content bytes.Buffer
+	for i := 0; i < b.Lines().Len(); i++ {
+		line := b.Lines().At(i)
+		content.Write(line.Value(source))
+	}
+	fields := strings.Fields(string(b.Info.Segment.Value(source)))
+	opts := parseCSVInfo(strings.ToLower(fields[0]), fields[1:])
+	if opts.delimiter == 0 {
+		opts.delimiter = SniffDelimiter(head(content.Bytes(), sniffLines))
+	}
+	records, err := newCSVReader(&content, opts.delimiter).ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil
+	}
+	table := &CSVTable{More: opts.more}
+	if opts.header > 0 || (opts.header == 0 && hasHeader(records)) {
+		table.Header, records = records[0], records[1:]
+	}
+	maxRows := t.maxRows
+	if maxRows <= 0 {
+		maxRows = DefaultCSVMaxRows
+	}
+	if len(records) > maxRows {
+		table.More += len(records) - maxRows
+		records = records[:maxRows]
+	}
+	table.Rows = records
+	cols := len(table.Header)
+	for _, row := range records {
+		cols = max(cols, len(row))
+	}
+	table.Numeric = numericColumns(records, cols)
+	return table
+}
+
+// csvRenderer 渲染表格, 有表头的表格可以点击表头排序(.sortable)
+type csvRenderer struct{}
+
+func (r csvRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindCSVTable, r.render)
+}
+
+func (r csvRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*CSVTable)
+	cols := len(n.Numeric)
+	cell := func(tag string, c int, value string) {
+		_, _ = w.WriteString("<" + tag)
+		if n.Numeric[c] {
+			_, _ = w.WriteString(` class="num"`)
+		}
+		_, _ = w.WriteString(">")
+		_, _ = w.Write(util.EscapeHTML([]byte(value)))
+		_, _ = w.WriteString("</" + tag + ">")
+	}
+	row := func(tag string, values []string) {
+		_, _ = w.WriteString("<tr>")
+		for c := 0; c < cols; c++ {
+			value := ""
+			if c < len(values) {
+				value = values[c]
+			}
+			cell(tag, c, value)
+		}
+		_, _ = w.WriteString("</tr>\n")
+	}
+	_, _ = w.WriteString(`<div class="csv-table">` + "\n")
+	if n.Header != nil {
+		_, _ = w.WriteString("<table class=\"sortable\">\n<thead>\n")
+		row("th", n.Header)
+		_, _ = w.WriteString("</thead>\n")
+	} else {
+		_, _ = w.WriteString("<table>\n")
+	}
+	_, _ = w.WriteString("<tbody>\n")
+	for _, values := range n.Rows {
+		row("td", values)
+	}
+	_, _ = w.WriteString("</tbody>\n</table>\n")
+	if n.More > 0 {
+		_, _ = w.WriteString(`<p class="csv-more">… ` + strconv.Itoa(n.More) + " more rows</p>\n")
+	}
+	_, _ = w.WriteString("</div>\n")
+	return ast.WalkSkipChildren, nil
+}
@@ -0,0 +1,97 @@
+package convert
+
+import "testing"
+
+func TestParseCSVInfo(t *testing.T) {
+	tests := []struct {
+		lang   string
+		fields []string
+		want   csvOptions
+	}{
+		{"csv", nil, csvOptions{}},
+		{"tsv", nil, csvOptions{delimiter: '\t'}},
+		{"csv", []string{"header"}, csvOptions{header: 1}},
+		{"csv", []string{"noheader"}, csvOptions{header: -1}},
+		{"csv", []string{"delimiter=;"}, csvOptions{delimiter: ';'}},
+		{"csv", []string{"delimiter=semicolon"}, csvOptions{delimiter: ';'}},
+		{"csv", []string{"delimiter=pipe"}, csvOptions{delimiter: '|'}},
+		{"csv", []string{`delimiter=\t`}, csvOptions{delimiter: '\t'}},
+		{"tsv", []string{"delimiter=comma"}, csvOptions{delimiter: ','}},
+		// 不是单个字符的分隔符忽略
+		{"csv", []string{"delimiter=;;"}, csvOptions{}},
+		{"csv", []string{"more=12", "header"}, csvOptions{header: 1, more: 12}},
+		{"csv", []string{"more=x", "unknown"}, csvOptions{}},
+	}
+	for _, tt := range tests {
+		if got := parseCSVInfo(tt.lang, tt.fields); got != tt.want {
+			t.Errorf("parseCSVInfo(%q, %q) = %+v, want %+v", tt.lang, tt.fields, got, tt.want)
+		}
+	}
+}
+
+func TestSniffDelimiter(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample string
+		want   rune
+	}{
+		{"comma", "a,b,c\n1,2,3\n", ','},
+		{"semicolon", "a;b;c\n1,5;2;3\n", ';'},
+		{"tab", "a\tb\n1\t2\n", '\t'},
+		{"pipe", "a|b|c\n1|2|3\n", '|'},
+		{"quoted comma", "a;b\n\"1,2\";3\n", ';'},
+		{"uneven rows", "a,b,c\n1,2\n", ','},
+		{"single column", "a\nb\n", ','},
+		{"empty", "", ','},
+	}
+	for _, tt := range tests {
+		if got := SniffDelimiter([]byte(tt.sample)); got != tt.want {
+			t.Errorf("%s: SniffDelimiter() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCSVMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		tsv     bool
+		maxRows int
+		want    string
+	}{
+		{"comma", "a,b\n1,2\n", false, 0, "```csv delimiter=comma\na,b\n1,2\n```\n"},
+		{"semicolon", "a;b\n1;2\n", false, 0, "```csv delimiter=semicolon\na;b\n1;2\n```\n"},
+		{"tsv", "a\tb\n1\t2\n", true, 0, "```csv delimiter=tab\na\tb\n1\t2\n```\n"},
+		// 表头之外保留maxRows行
+		{"truncated", "a\n1\n2\n3\n4\n", false, 2, "```csv delimiter=comma more=2\na\n1\n2\n```\n"},
+		{"backticks", "a,b\n```,x\n", false, 0, "````csv delimiter=comma\na,b\n```,x\n````\n"},
+	}
+	for _, tt := range tests {
+		got, err := CSVMarkdown([]byte(tt.content), tt.tsv, tt.maxRows)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: CSVMarkdown() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHasHeader(t *testing.T) {
+	tests := []struct {
+		records [][]string
+		want    bool
+	}{
+		{[][]string{{"name", "age"}, {"ann", "30"}}, true},
+		{[][]string{{"name", "30"}, {"ann", "30"}}, false},
+		{[][]string{{"name", ""}, {"ann", "30"}}, false},
+		{[][]string{{"x", "x"}, {"ann", "30"}}, false},
+		{[][]string{{"name", "age"}}, false},
+	}
+	for _, tt := range tests {
+		if got := hasHeader(tt.records); got != tt.want {
+			t.Errorf("hasHeader(%q) = %v, want %v", tt.records, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package convert
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FrontMatter 解析文件开头由 --- 包裹的 yaml 元数据(与 goldmark-meta 相同的格式),
+// 没有元数据或者解析失败时返回 nil
+func FrontMatter(src []byte) map[string]interface{} {
+	metaData, _ := ParseFrontMatter(src)
+	return metaData
+}
+
+// ParseFrontMatter 和 FrontMatter 相同, 有元数据但是 yaml 解析失败时返回错误
+func ParseFrontMatter(src []byte) (map[string]interface{}, error) {
+	src = bytes.TrimPrefix(src, []byte("\xef\xbb\xbf"))
+	if !bytes.HasPrefix(src, []byte("---")) {
+		return nil, nil
+	}
+	lines := bytes.SplitAfter(src, []byte("\n"))
+	if len(lines) < 2 || string(bytes.TrimSpace(lines[0])) != "---" {
+		return nil, nil
+	}
+	var block bytes.Buffer
+	for _, line := range lines[1:] {
+		trimmed := bytes.TrimSpace(line)
+		if string(trimmed) == "---" || string(trimmed) == "..." {
+			metaData := map[string]interface{}{}
+			if err := yaml.Unmarshal(block.Bytes(), &metaData); err != nil {
+				return nil, fmt.Errorf("front matter: %w", err)
+			}
+			return metaData, nil
+		}
+		block.Write(line)
+	}
+	return nil, nil
+}
+
+// MetaValue 按顺序返回第一个存在的元数据
+func MetaValue(metaData map[string]interface{}, keys ...string) (interface{}, bool) {
+	for _, key := range keys {
+		if value, ok := metaData[key]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// MetaString 按顺序返回第一个存在的元数据的字符串形式
+func MetaString(metaData map[string]interface{}, keys ...string) string {
+	if value, ok := MetaValue(metaData, keys...); ok && value != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return ""
+}
+
+// MetaStrings 按顺序返回第一个存在的元数据的字符串列表, 字符串按逗号分隔
+func MetaStrings(metaData map[string]interface{}, keys ...string) []string {
+	value, ok := MetaValue(metaData, keys...)
+	if !ok || value == nil {
+		return nil
+	}
+	var values []string
+	if list, ok := value.([]interface{}); ok {
+		for _, v := range list {
+			if s := strings.TrimSpace(fmt.Sprintf("%v", v)); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	for _, s := range strings.Split(fmt.Sprintf("%v", value), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,172 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mozillazg/go-pinyin"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// 标题id的生成方式
+const (
+	// HeadingIDUnicode 保留中文等字符: "安装 Go" => "安装-go"
+	HeadingIDUnicode = "unicode"
+	// HeadingIDPinyin 中文转换为拼音: "安装 Go" => "an-zhuang-go"
+	HeadingIDPinyin = "pinyin"
+	// HeadingIDASCII 只保留ASCII字母和数字(goldmark默认的方式): "安装 Go" => "go"
+	HeadingIDASCII = "ascii"
+)
+
+// ValidHeadingIDs 是否是支持的标题id生成方式
+func ValidHeadingIDs(mode string) bool {
+	switch mode {
+	case "", HeadingIDUnicode, HeadingIDPinyin, HeadingIDASCII:
+		return true
+	}
+	return false
+}
+
+var pinyinArgs = pinyin.NewArgs()
+
+// headingIDs 生成文档中标题的id, 重复的id加上 -1 -2 ...; {#id} 指定的id也会被记录
+type headingIDs struct {
+	mode   string
+	values map[string]bool
+}
+
+func newHeadingIDs(mode string) parser.IDs {
+	if mode == "" {
+		mode = HeadingIDUnicode
+	}
+	return &headingIDs{mode: mode, values: map[string]bool{}}
+}
+
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	result := slugify(string(util.TrimRightSpace(util.TrimLeftSpace(value))), s.mode)
+	if result == "" {
+		if kind == ast.KindHeading {
+			result = "heading"
+		} else {
+			result = "id"
+		}
+	}
+	if !s.values[result] {
+		s.values[result] = true
+		return []byte(result)
+	}
+	for i := 1; ; i++ {
+		next := fmt.Sprintf("%s-%d", result, i)
+		if !s.values[next] {
+			s.values[next] = true
+			return []byte(next)
+		}
+	}
+}
+
+func (s *headingIDs) Put(value []byte) {
+	s.values[string(value)] = true
+}
+
+// slugify 字母和数字转换为小写, 空白、- 和 _ 转换为 -, 其它字符去掉.
+// ASCII字符的结果和goldmark默认的方式相同, 已有的英文标题的锚点不会变化.
+func slugify(value, mode string) string {
+	var b strings.Builder
+	afterPinyin := false
+	for _, r := range value {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if afterPinyin {
+				b.WriteByte('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			afterPinyin = false
+		case r < unicode.MaxASCII && (unicode.IsSpace(r) || r == '-' || r == '_'):
+			b.WriteByte('-')
+			afterPinyin = false
+		case mode == HeadingIDASCII:
+			// goldmark 忽略所有多字节字符
+		case mode == HeadingIDPinyin && unicode.Is(unicode.Han, r):
+			if py := pinyin.SinglePinyin(r, pinyinArgs); len(py) > 0 {
+				if s := b.String(); s != "" && !strings.HasSuffix(s, "-") {
+					b.WriteByte('-')
+				}
+				b.WriteString(py[0])
+				afterPinyin = true
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r):
+			if afterPinyin {
+				b.WriteByte('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			afterPinyin = false
+		case unicode.IsSpace(r):
+			b.WriteByte('-')
+			afterPinyin = false
+		}
+	}
+	return b.String()
+}
+
+// KindPermalink 标题的锚点链接
+var KindPermalink = ast.NewNodeKind("Permalink")
+
+// Permalink 标题后面指向标题的锚点链接
+type Permalink struct {
+	ast.BaseInline
+	ID     []byte
+	Symbol string
+}
+
+func (n *Permalink) Kind() ast.NodeKind {
+	return KindPermalink
+}
+
+func (n *Permalink) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"ID": string(n.ID)}, nil)
+}
+
+// permalinkTransformer 在有id的标题后面加上锚点链接, 需要在生成目录之后执行
+type permalinkTransformer struct {
+	symbol string
+}
+
+func (t permalinkTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		if id, ok := heading.AttributeString("id"); ok {
+			if value, ok := id.([]byte); ok && len(value) > 0 {
+				heading.AppendChild(heading, &Permalink{ID: value, Symbol: t.symbol})
+			}
+		}
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// permalinkRenderer 渲染锚点链接
+type permalinkRenderer struct{}
+
+func (r permalinkRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindPermalink, r.render)
+}
+
+func (r permalinkRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*Permalink)
+	_, _ = w.WriteString(` <a class="anchor" href="#`)
+	_, _ = w.Write(util.EscapeHTML(n.ID))
+	_, _ = w.WriteString(`" aria-hidden="true">`)
+	_, _ = w.Write(util.EscapeHTML([]byte(n.Symbol)))
+	_, _ = w.WriteString(`</a>`)
+	return ast.WalkSkipChildren, nil
+}
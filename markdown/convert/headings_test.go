@@ -0,0 +1,54 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		value, mode, want string
+	}{
+		{"Getting Started", HeadingIDUnicode, "getting-started"},
+		{"foo_bar-baz", HeadingIDUnicode, "foo-bar-baz"},
+		{"What's new?", HeadingIDUnicode, "whats-new"},
+		{"安装 Go", HeadingIDUnicode, "安装-go"},
+		{"Größe", HeadingIDUnicode, "größe"},
+		{"安装 Go", HeadingIDPinyin, "an-zhuang-go"},
+		{"Go安装", HeadingIDPinyin, "go-an-zhuang"},
+		{"安装Go 1.22", HeadingIDPinyin, "an-zhuang-go-122"},
+		{"安装 Go", HeadingIDASCII, "-go"},
+		{"Getting Started", HeadingIDASCII, "getting-started"},
+		{"！？", HeadingIDUnicode, ""},
+	}
+	for _, tt := range tests {
+		if got := slugify(tt.value, tt.mode); got != tt.want {
+			t.Errorf("slugify(%q, %q) = %q, want %q", tt.value, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestHeadingIDs(t *testing.T) {
+	ids := newHeadingIDs("")
+	ids.Put([]byte("custom"))
+	tests := []struct {
+		value string
+		kind  ast.NodeKind
+		want  string
+	}{
+		{"Intro", ast.KindHeading, "intro"},
+		{"Intro", ast.KindHeading, "intro-1"},
+		{"Intro", ast.KindHeading, "intro-2"},
+		{"Custom", ast.KindHeading, "custom-1"},
+		{"  安装  ", ast.KindHeading, "安装"},
+		{"？", ast.KindHeading, "heading"},
+		{"？", ast.KindHeading, "heading-1"},
+		{"？", ast.KindParagraph, "id"},
+	}
+	for _, tt := range tests {
+		if got := string(ids.Generate([]byte(tt.value), tt.kind)); got != tt.want {
+			t.Errorf("Generate(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
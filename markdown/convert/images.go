@@ -0,0 +1,85 @@
+package convert
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// ImageInfo 本地图片的尺寸和不同宽度的版本
+type ImageInfo struct {
+	Width  int
+	Height int
+	Srcset []ImageSource
+}
+
+// ImageSource srcset中的一项
+type ImageSource struct {
+	URL   string
+	Width int
+}
+
+// ImageResolver 返回markdown中图片地址dest对应的本地图片, 不是本地图片时返回false
+type ImageResolver func(dest string) (ImageInfo, bool)
+
+var imagesKey = parser.NewContextKey()
+
+// WithImages 为本地图片加上 srcset、width/height 和 loading="lazy"
+func WithImages(resolve ImageResolver) ConvertOption {
+	return func(pc parser.Context) {
+		pc.Set(imagesKey, resolve)
+	}
+}
+
+// imageTransformer 设置本地图片的属性, 由默认的图片渲染输出
+type imageTransformer struct{}
+
+func (imageTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	resolve, _ := pc.Get(imagesKey).(ImageResolver)
+	if resolve == nil {
+		return
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		img, ok := n.(*ast.Image)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		info, ok := resolve(string(img.Destination))
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		if info.Width > 0 && info.Height > 0 {
+			img.SetAttributeString("width", strconv.Itoa(info.Width))
+			img.SetAttributeString("height", strconv.Itoa(info.Height))
+		}
+		img.SetAttributeString("loading", "lazy")
+		img.SetAttributeString("decoding", "async")
+		if len(info.Srcset) > 1 {
+			var srcset []string
+			for _, src := range info.Srcset {
+				srcset = append(srcset, src.URL+" "+strconv.Itoa(src.Width)+"w")
+			}
+			img.SetAttributeString("srcset", strings.Join(srcset, ", "))
+			img.SetAttributeString("sizes", "(max-width: "+strconv.Itoa(info.Width)+"px) 100vw, "+strconv.Itoa(info.Width)+"px")
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+var firstImageKey = parser.NewContextKey()
+
+// firstImageCollector 记录文档中的第一张图片, 作为分享时的预览图片
+type firstImageCollector struct{}
+
+func (firstImageCollector) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if img, ok := n.(*ast.Image); ok && entering && len(img.Destination) > 0 {
+			pc.Set(firstImageKey, string(img.Destination))
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+}
@@ -0,0 +1,268 @@
+package convert
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultIncludeDepth 默认最多嵌套包含的层数
+const DefaultIncludeDepth = 8
+
+var (
+	// {{< include "path/to/file.md#heading" >}}
+	includeShortcode = regexp.MustCompile(`^\{\{<\s*include\s+"([^"]*)"\s*>\}\}$`)
+	// ![[file#heading]] 或者 ![[file#heading|别名]]
+	includeEmbed = regexp.MustCompile(`^!\[\[([^\]|]*)(?:\|[^\]]*)?\]\]$`)
+)
+
+// IncludeFile 包含的文件, 修改时间用于页面的缓存
+type IncludeFile struct {
+	Name    string    `json:"-" remark:"文件系统中的文件名"`
+	ModTime time.Time `json:"modTime" remark:"修改时间"`
+}
+
+// Includer 在渲染前把文档中单独一行的 {{< include "file.md#heading" >}} 或 ![[file#heading]]
+// 替换为文件(或者文件中某个标题的章节)的内容. 相对路径从包含它的文件所在目录开始, / 开头的路径从Root开始.
+type Includer struct {
+	FS   fs.FS
+	Root string
+	// 最多嵌套包含的层数. Default: DefaultIncludeDepth
+	MaxDepth int
+	// Allow 是否可以包含该文件(例如访问控制), err 为元数据的解析错误; 为nil时都可以包含
+	Allow func(filename string, metaData map[string]interface{}, err error) bool
+
+	files []IncludeFile
+}
+
+// Expand 展开文件filename的内容src中的包含, 返回展开后的内容和包含的文件.
+// 包含失败(文件不存在、循环包含、超过层数)时在该位置显示错误, 不影响文档其它部分.
+func (in *Includer) Expand(filename, src string) (string, []IncludeFile) {
+	in.files = nil
+	out := in.expand(filename, src, []string{filename + "#"})
+	return out, in.files
+}
+
+func (in *Includer) expand(filename, src string, stack []string) string {
+	if !strings.Contains(src, "{{<") && !strings.Contains(src, "![[") {
+		return src
+	}
+	lines := strings.SplitAfter(src, "\n")
+	var b strings.Builder
+	fence := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			b.WriteString(line)
+			continue
+		}
+		if f := codeFence(trimmed); f != "" {
+			fence = f
+			b.WriteString(line)
+			continue
+		}
+		target, ok := includeTarget(trimmed)
+		if !ok {
+			b.WriteString(line)
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		content := in.include(filename, target, stack)
+		content = strings.TrimRight(content, "\n")
+		for _, l := range strings.SplitAfter(content, "\n") {
+			if strings.TrimSpace(l) != "" {
+				b.WriteString(indent)
+			}
+			b.WriteString(l)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// include 返回包含的内容, 失败时返回错误提示
+func (in *Includer) include(filename, target string, stack []string) string {
+	name, heading, _ := strings.Cut(target, "#")
+	switch {
+	case name == "":
+		name = filename
+	case strings.HasPrefix(name, "/"):
+		name = path.Join(in.Root, name)
+	default:
+		name = path.Join(path.Dir(filename), name)
+	}
+	if path.Ext(name) == "" {
+		name += ".md"
+	}
+	if root := path.Clean(in.Root); root != "." && name != root && !strings.HasPrefix(name, root+"/") {
+		return includeError(target, "not found")
+	}
+	if !isMarkdownName(name) {
+		return includeError(target, "not a markdown file")
+	}
+
+	key := name + "#" + strings.ToLower(heading)
+	for _, s := range stack {
+		if s == key {
+			return includeError(target, "include cycle")
+		}
+	}
+	maxDepth := in.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultIncludeDepth
+	}
+	if len(stack) > maxDepth {
+		return includeError(target, "too many nested includes")
+	}
+
+	info, err := fs.Stat(in.FS, name)
+	if err != nil || info.IsDir() {
+		return includeError(target, "not found")
+	}
+	content, err := fs.ReadFile(in.FS, name)
+	if err != nil {
+		return includeError(target, "not found")
+	}
+	src := string(content)
+	if in.Allow != nil {
+		if metaData, err := ParseFrontMatter(content); !in.Allow(name, metaData, err) {
+			// 不暴露没有权限的文件是否存在
+			return includeError(target, "not found")
+		}
+	}
+	in.files = append(in.files, IncludeFile{Name: name, ModTime: info.ModTime()})
+
+	src = stripFrontMatter(src)
+	if heading != "" {
+		section, ok := headingSection(src, heading)
+		if !ok {
+			return includeError(target, "heading not found")
+		}
+		src = section
+	}
+	return in.expand(name, src, append(stack, key))
+}
+
+// includeTarget 返回单独一行的包含指令中的目标
+func includeTarget(line string) (string, bool) {
+	if m := includeShortcode.FindStringSubmatch(line); m != nil {
+		return strings.TrimSpace(m[1]), true
+	}
+	if m := includeEmbed.FindStringSubmatch(line); m != nil {
+		// ![[image.png]] 等其它文件不是包含
+		name, _, _ := strings.Cut(strings.TrimSpace(m[1]), "#")
+		if path.Ext(name) != "" && !isMarkdownName(name) {
+			return "", false
+		}
+		return strings.TrimSpace(m[1]), true
+	}
+	return "", false
+}
+
+func includeError(target, msg string) string {
+	return fmt.Sprintf("> include `%s`: %s\n", strings.ReplaceAll(target, "`", ""), msg)
+}
+
+func isMarkdownName(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// codeFence 代码块开始的行返回结束的标记(``` 或 ~~~)
+func codeFence(line string) string {
+	for _, f := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// stripFrontMatter 去掉开头的元数据
+func stripFrontMatter(src string) string {
+	src = strings.TrimPrefix(src, "\xef\xbb\xbf")
+	if !strings.HasPrefix(src, "---") {
+		return src
+	}
+	lines := strings.SplitAfter(src, "\n")
+	if strings.TrimSpace(lines[0]) != "---" {
+		return src
+	}
+	offset := len(lines[0])
+	for _, line := range lines[1:] {
+		offset += len(line)
+		if t := strings.TrimSpace(line); t == "---" || t == "..." {
+			return src[offset:]
+		}
+	}
+	return src
+}
+
+// headingSection 返回标题heading(标题文本或者锚点)的章节: 从标题开始到下一个同级或更高级的标题
+func headingSection(src, heading string) (string, bool) {
+	lines := strings.SplitAfter(src, "\n")
+	start, level := -1, 0
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if f := codeFence(trimmed); f != "" {
+			fence = f
+			continue
+		}
+		l, text := atxHeading(line)
+		if l == 0 {
+			continue
+		}
+		if start >= 0 && l <= level {
+			return strings.Join(lines[start:i], ""), true
+		}
+		if start < 0 && headingMatch(text, heading) {
+			start, level = i, l
+		}
+	}
+	if start < 0 {
+		return "", false
+	}
+	return strings.Join(lines[start:], ""), true
+}
+
+// atxHeading 返回 # 标题的级别和文本, 不是标题时级别为0
+func atxHeading(line string) (int, string) {
+	if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+		return 0, ""
+	}
+	line = strings.TrimSpace(line)
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ' && line[level] != '\t') {
+		return 0, ""
+	}
+	text := strings.TrimSpace(line[level:])
+	// 去掉结尾的 #
+	if trimmed := strings.TrimRight(text, "#"); trimmed != text && (trimmed == "" || strings.HasSuffix(trimmed, " ")) {
+		text = strings.TrimSpace(trimmed)
+	}
+	return level, text
+}
+
+// headingMatch 标题文本或者锚点(空格替换为-, 忽略大小写)是否匹配
+func headingMatch(text, want string) bool {
+	normalize := func(s string) string {
+		return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(s, "-", " "))), "-")
+	}
+	return strings.EqualFold(text, want) || normalize(text) == normalize(want)
+}
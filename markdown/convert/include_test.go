@@ -0,0 +1,80 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestIncluderExpand(t *testing.T) {
+	fsys := fstest.MapFS{
+		"site/a.md":       {Data: []byte("A\n{{< include \"b.md\" >}}\n")},
+		"site/b.md":       {Data: []byte("B\n![[a]]\n")},
+		"site/self.md":    {Data: []byte("self\n![[self.md]]\n")},
+		"site/doc.md":     {Data: []byte("# Doc\n\n## One\none\n![[#One]]\n\n## Two\ntwo\n")},
+		"site/part.md":    {Data: []byte("---\ntitle: Part\n---\npart\n")},
+		"site/secret.md":  {Data: []byte("---\naccess: [team]\n---\nsecret\n")},
+		"site/broken.md":  {Data: []byte("---\naccess: [team\n---\nbroken\n")},
+		"site/deep/0.md":  {Data: []byte("0\n![[1]]\n")},
+		"site/deep/1.md":  {Data: []byte("1\n![[2]]\n")},
+		"site/deep/2.md":  {Data: []byte("2\n![[3]]\n")},
+		"site/deep/3.md":  {Data: []byte("3\n")},
+		"site/guide/x.md": {Data: []byte("x\n")},
+		"outside.md":      {Data: []byte("outside\n")},
+	}
+	allow := func(name string, metaData map[string]interface{}, err error) bool {
+		return err == nil && MetaString(metaData, "access") == ""
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		src      string
+		want     string
+	}{
+		{"cycle", "site/x.md", "![[a]]\n", "A\nB\n> include `a`: include cycle\n"},
+		{"self", "site/self.md", "![[self]]\n", "> include `self`: include cycle\n"},
+		{"section of the same file", "site/doc.md", "![[#One]]\n", "## One\none\n> include `#One`: include cycle\n"},
+		{"front matter is stripped", "site/x.md", "![[part]]\n", "part\n"},
+		{"absolute path", "site/guide/y.md", "![[/guide/x]]\n", "x\n"},
+		{"indented", "site/x.md", "- item\n  ![[part]]\n", "- item\n  part\n"},
+		{"max depth", "site/deep/start.md", "![[0]]\n", "0\n1\n> include `2`: too many nested includes\n"},
+		{"not found", "site/x.md", "![[missing]]\n", "> include `missing`: not found\n"},
+		{"outside the root", "site/x.md", "![[../outside]]\n", "> include `../outside`: not found\n"},
+		{"heading not found", "site/x.md", "![[doc#Three]]\n", "> include `doc#Three`: heading not found\n"},
+		{"not allowed", "site/x.md", "![[secret]]\n", "> include `secret`: not found\n"},
+		{"malformed front matter", "site/x.md", "![[broken]]\n", "> include `broken`: not found\n"},
+		{"not markdown", "site/x.md", "{{< include \"a.txt\" >}}\n", "> include `a.txt`: not a markdown file\n"},
+		{"code block", "site/x.md", "```\n![[part]]\n```\n", "```\n![[part]]\n```\n"},
+		{"image embed", "site/x.md", "![[photo.png]]\n", "![[photo.png]]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &Includer{FS: fsys, Root: "site", MaxDepth: 2, Allow: allow}
+			got, _ := in.Expand(tt.filename, tt.src)
+			if got != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncluderFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.md": {Data: []byte("![[b]]\n![[b]]\n")},
+		"b.md": {Data: []byte("b\n")},
+	}
+	in := &Includer{FS: fsys, Root: "."}
+	_, files := in.Expand("x.md", "![[a]]\n")
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+	if want := []string{"a.md", "b.md", "b.md"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("files = %q, want %q", names, want)
+	}
+	// 再次展开时不保留上次的文件
+	if _, files := in.Expand("x.md", "x\n"); len(files) != 0 {
+		t.Errorf("files = %v, want none", files)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	katex "github.com/kingreatwill/goldmark-katex"
 	"github.com/yuin/goldmark"
@@ -13,66 +14,219 @@ import (
 	meta "github.com/yuin/goldmark-meta"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
 	"go.abhg.dev/goldmark/mermaid"
-	"go.abhg.dev/goldmark/toc"
 )
 
 type MarkdownConvert struct {
 	engine goldmark.Markdown
+
+	headingIDs string // 标题id的生成方式
+	permalink  string // 标题后面锚点链接的符号, 为空时不显示
+
+	wordsPerMinute    int // 每分钟阅读的单词数
+	cjkCharsPerMinute int // 每分钟阅读的中日韩文字数
+
+	csvMaxRows int // csv/tsv表格最多显示的行数
+}
+
+// Option 设置转换器的选项
+type Option func(*MarkdownConvert)
+
+// WithHeadingIDs 标题id的生成方式: unicode(默认), pinyin, ascii
+func WithHeadingIDs(mode string) Option {
+	return func(c *MarkdownConvert) {
+		c.headingIDs = mode
+	}
+}
+
+// WithPermalinks 在标题后面显示指向标题的锚点链接
+func WithPermalinks(symbol string) Option {
+	return func(c *MarkdownConvert) {
+		c.permalink = symbol
+	}
+}
+
+// WithReadingSpeed 估算阅读时间使用的阅读速度, 为0时使用默认值
+func WithReadingSpeed(wordsPerMinute, cjkCharsPerMinute int) Option {
+	return func(c *MarkdownConvert) {
+		c.wordsPerMinute = wordsPerMinute
+		c.cjkCharsPerMinute = cjkCharsPerMinute
+	}
 }
 
-func New() *MarkdownConvert {
-	md := goldmark.New(
+// WithCSVMaxRows csv/tsv表格最多显示的行数, 为0时使用默认值
+func WithCSVMaxRows(rows int) Option {
+	return func(c *MarkdownConvert) {
+		c.csvMaxRows = rows
+	}
+}
+
+func New(opts ...Option) *MarkdownConvert {
+	c := &MarkdownConvert{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	transformers := []util.PrioritizedValue{
+		util.Prioritized(csvTransformer{maxRows: c.csvMaxRows}, 20),
+		util.Prioritized(printTransformer{}, 40),
+		// 优先级数值小于 tocInserter(100), 在插入目录之前执行
+		util.Prioritized(tocCollector{}, 50),
+		util.Prioritized(statsCollector{}, 55),
+		util.Prioritized(firstImageCollector{}, 56),
+		util.Prioritized(imageTransformer{}, 60),
+		// 在插入目录和处理mermaid之前分页, 目录和mermaid的<script>在幻灯片之外
+		util.Prioritized(slidesTransformer{}, 90),
+		util.Prioritized(&tocInserter{}, 100),
+	}
+	if c.permalink != "" {
+		// 在插入目录之后执行, 目录的标题中不包含锚点链接
+		transformers = append(transformers, util.Prioritized(permalinkTransformer{symbol: c.permalink}, 200))
+	}
+	c.engine = goldmark.New(
 		goldmark.WithExtensions(
 			extension.GFM,
-			extension.Footnote,
+			extension.NewFootnote(extension.WithFootnoteIDPrefixFunction(footnoteIDPrefix)),
 			katex.KaTeX,
 			emoji.Emoji,
 			//mathjax.MathJax,
 			highlighting.Highlighting,
-			&toc.Extender{},
 			&mermaid.Extender{},
 			meta.Meta,
+			ShortcodeExtension,
+			AdmonitionExtension,
 		),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(),
+			// ## 标题 {#id}
+			parser.WithHeadingAttribute(),
+			parser.WithASTTransformers(transformers...),
 		),
 		goldmark.WithRendererOptions(
 			html.WithHardWraps(),
 			html.WithXHTML(),
+			renderer.WithNodeRenderers(util.Prioritized(permalinkRenderer{}, 100), util.Prioritized(csvRenderer{}, 100),
+				util.Prioritized(slidesRenderer{}, 100)),
 		),
 	)
-	return &MarkdownConvert{
-		engine: md,
-	}
+	return c
 }
 
 type TemplateData struct {
-	SiteUrl     string `remark:"站点地址"`
-	Title       string `remark:"<title>Title"`
-	Keywords    string `remark:"<meta>Keywords逗号隔开"`
-	Description string `remark:"<meta>description"`
-	HasKatex    bool   `remark:"md中是否解析了katex"`
-	HasMermaid  bool   `remark:"md中是否解析了mermaid"`
+	SiteUrl     string `json:"siteUrl" remark:"站点地址"`
+	Title       string `json:"title" remark:"<title>Title"`
+	Keywords    string `json:"keywords" remark:"<meta>Keywords逗号隔开"`
+	Description string `json:"description" remark:"<meta>description"`
+	HasKatex    bool   `json:"hasKatex" remark:"md中是否解析了katex"`
+	HasMermaid  bool   `json:"hasMermaid" remark:"md中是否解析了mermaid"`
+
+	CurrentDirs   []TemplateFileItemData `json:"currentDirs" remark:"路径"`
+	CurrentFile   string                 `json:"-" remark:"当前渲染文件(也有可能是目录), 文件系统中的路径"`
+	CurrentIsFile bool                   `json:"currentIsFile" remark:"是否有渲染文件"`
+	Content       []byte                 `json:"-" remark:"md"`
+	MdHtml        string                 `json:"html" remark:"html"`
+	UpperPath     string                 `json:"upperPath" remark:"上一级连接"`
+	Sort          string                 `json:"sort" remark:"目录排序字段:name,mtime,size,title"`
+	Order         string                 `json:"order" remark:"目录排序方向:asc,desc"`
+	Page          int                    `json:"page" remark:"目录当前页,从1开始"`
+	PageCount     int                    `json:"pageCount" remark:"目录总页数"`
+	PageSize      int                    `json:"pageSize" remark:"目录每页条数,0表示不分页"`
+	TotalItems    int                    `json:"totalItems" remark:"目录条目总数"`
+
+	GitStartDate string `json:"gitStartDate" remark:"热力图的开始日期(11个月前的月初)"`
+	GitStatsData string `json:"gitStatsData" remark:"当前目录或文件最近12个月的每日提交数量"`
+
+	Meta map[string]interface{} `json:"meta,omitempty" remark:"markdown元数据(front matter)"`
+	Toc  []TocItem              `json:"toc,omitempty" remark:"目录(Table of Contents)"`
+
+	WordCount   int `json:"wordCount,omitempty" remark:"字数(中日韩文字按字计数, 不包含代码块)"`
+	CharCount   int `json:"charCount,omitempty" remark:"字符数(不包含空白和代码块)"`
+	ReadingTime int `json:"readingTime,omitempty" remark:"预计阅读时间(分钟)"`
+
+	Version  string        `json:"version,omitempty" remark:"当前文档版本"`
+	Versions []VersionItem `json:"versions,omitempty" remark:"版本切换列表"`
+
+	Lang      string         `json:"lang,omitempty" remark:"当前文档的语言"`
+	Languages []LanguageItem `json:"languages,omitempty" remark:"语言切换列表(hreflang)"`
 
-	CurrentDirs   []TemplateFileItemData `remark:"路径"`
-	CurrentFile   string                 `remark:"当前渲染文件(也有可能是目录)"`
-	CurrentIsFile bool                   `remark:"是否有渲染文件"`
-	Content       []byte                 `remark:"md"`
-	MdHtml        string                 `remark:"html"`
-	UpperPath     string                 `remark:"上一级连接"`
+	Includes []IncludeFile `json:"-" remark:"文档中包含的其它文件"`
 
-	GitStartDate string `remark:"一年前的日期"`
-	GitStatsData string `remark:"截止到目前为止所有的每日提交数量"`
+	Prev *PageLink `json:"prev,omitempty" remark:"按阅读顺序的上一篇文档"`
+	Next *PageLink `json:"next,omitempty" remark:"按阅读顺序的下一篇文档"`
+
+	Tree []TreeNode `json:"tree,omitempty" remark:"全站的导航树(配置了tree时)"`
+
+	Recent []RecentDoc `json:"recent,omitempty" remark:"目录下最近修改的文档(配置了recent时, 目录和目录的index文件)"`
+
+	Canonical string    `json:"canonical,omitempty" remark:"规范连接(site_url加上路径)"`
+	Image     string    `json:"image,omitempty" remark:"预览图片: 元数据中的image/cover或者文档中的第一张图片(绝对地址)"`
+	MetaTags  []MetaTag `json:"metaTags,omitempty" remark:"Open Graph(og:*)和Twitter Card(twitter:*)的<meta>"`
+	JSONLD    string    `json:"jsonLd,omitempty" remark:"Article和BreadcrumbList的JSON-LD"`
+	SEOHead   string    `json:"-" remark:"可以直接放在<head>中的canonical连接、<meta>和JSON-LD"`
+
+	Status     int    `json:"status,omitempty" remark:"错误页面(_404.md/_error.md)的状态码"`
+	StatusText string `json:"statusText,omitempty" remark:"错误页面的状态, 例如 Not Found"`
+
+	Slides bool `json:"slides,omitempty" remark:"幻灯片模式(?slides或元数据layout: slides), MdHtml中每页为<section class=\"slide\">"`
+	Print  bool `json:"print,omitempty" remark:"目录的打印页面(?print), MdHtml中每个文档为<section class=\"print-doc\">, Toc为合并的目录"`
+}
+
+type MetaTag struct {
+	Attr    string `json:"attr" remark:"property(og:*)或者name(twitter:*)"`
+	Key     string `json:"key" remark:"例如 og:title"`
+	Content string `json:"content" remark:"内容"`
+}
+
+type TreeNode struct {
+	Title    string     `json:"title" remark:"标题"`
+	Href     string     `json:"href,omitempty" remark:"连接, _sidebar.md中没有连接的分组为空"`
+	IsDir    bool       `json:"isDir,omitempty" remark:"是否目录"`
+	Current  bool       `json:"current,omitempty" remark:"是否当前页面"`
+	Expanded bool       `json:"expanded,omitempty" remark:"是否展开(当前页面在其中)"`
+	Children []TreeNode `json:"children,omitempty" remark:"子节点"`
+}
+
+type RecentDoc struct {
+	Title    string    `json:"title" remark:"标题(元数据中的title或文件名)"`
+	Href     string    `json:"href" remark:"连接"`
+	Summary  string    `json:"summary,omitempty" remark:"元数据中的summary/description"`
+	Author   string    `json:"author,omitempty" remark:"最后一次提交的作者, 没有git记录时为元数据中的author"`
+	Modified time.Time `json:"modified" remark:"最后一次提交的时间, 没有git记录时为文件的修改时间"`
+	Commit   string    `json:"commit,omitempty" remark:"最后一次修改的提交"`
+}
+
+type PageLink struct {
+	Title string `json:"title" remark:"标题(元数据中的title或文件名)"`
+	Href  string `json:"href" remark:"连接"`
+}
+
+type LanguageItem struct {
+	Lang    string `json:"lang" remark:"语言"`
+	Href    string `json:"href" remark:"该语言版本的连接"`
+	Current bool   `json:"current" remark:"是否当前语言"`
+}
+
+type VersionItem struct {
+	Name    string `json:"name" remark:"标签或分支名"`
+	Href    string `json:"href" remark:"该版本中当前页面的连接"`
+	Current bool   `json:"current" remark:"是否当前版本"`
 }
 
 type TemplateFileItemData struct {
-	FileExtension string `remark:"文件后缀名"`
-	IsFile        bool   `remark:"是否文件"`
-	Name          string `remark:"文件或目录名"`
-	Href          string `remark:"连接,不带SiteUrl"`
-	Icon          string `remark:"Icon"`
+	FileExtension string    `json:"fileExtension" remark:"文件后缀名"`
+	IsFile        bool      `json:"isFile" remark:"是否文件"`
+	Name          string    `json:"name" remark:"文件或目录名"`
+	Href          string    `json:"href" remark:"连接,不带SiteUrl"`
+	Icon          string    `json:"icon" remark:"Icon"`
+	Size          int64     `json:"size" remark:"文件大小"`
+	ModTime       time.Time `json:"modTime" remark:"修改时间"`
+	Title         string    `json:"title" remark:"markdown元数据中的title"`
+	Summary       string    `json:"summary" remark:"markdown元数据中的summary/description"`
+	ChildCount    int       `json:"childCount" remark:"目录下的条目数"`
+	Weight        int       `json:"weight,omitempty" remark:"元数据中的weight/order(目录为index文件的), 0表示没有"`
+	Languages     []string  `json:"languages,omitempty" remark:"文档的所有语言版本"`
 }
 
 var bufPool = sync.Pool{
@@ -81,30 +235,51 @@ var bufPool = sync.Pool{
 	},
 }
 
-func (c *MarkdownConvert) Convert(mdStr string, data *TemplateData) (err error) {
+// 超过maxPooledBuffer的缓冲区不放回bufPool
+const maxPooledBuffer = 1 << 20
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufPool.Put(buf)
+	}
+}
+
+// ConvertOption 设置本次转换的选项
+type ConvertOption func(pc parser.Context)
+
+func (c *MarkdownConvert) Convert(mdStr string, data *TemplateData, opts ...ConvertOption) (err error) {
 
 	data.Content = []byte(mdStr)
 
 	// var buf bytes.Buffer
 	buf := bufPool.Get().(*bytes.Buffer)
 	buf.Reset()
-	defer bufPool.Put(buf)
+	defer putBuffer(buf)
 
-	context := parser.NewContext()
+	context := parser.NewContext(parser.WithIDs(newHeadingIDs(c.headingIDs)))
+	for _, opt := range opts {
+		opt(context)
+	}
 	if err = c.engine.Convert(data.Content, buf, parser.WithContext(context)); err != nil {
 		return err
 	}
 
 	data.MdHtml = buf.String()
+	data.Toc = getToc(context)
+	stats := getStats(context)
+	data.WordCount = stats.Words
+	data.CharCount = stats.Chars
+	data.ReadingTime = stats.ReadingTime(c.wordsPerMinute, c.cjkCharsPerMinute)
 
 	metaData := meta.Get(context)
-	if value, ok := metaData["Title"]; ok {
-		data.Title = fmt.Sprintf("%v", value)
-	} else if value, ok := metaData["title"]; ok {
-		data.Title = fmt.Sprintf("%v", value)
+	if metaData != nil {
+		data.Meta = normalizeMeta(metaData).(map[string]interface{})
+	}
+	if value := MetaString(metaData, "Title", "title"); value != "" {
+		data.Title = value
 	}
 
-	keywordsFunc := func(value interface{}) {
+	if value, ok := MetaValue(metaData, "Keywords", "keywords", "Tags", "tags"); ok {
 		if newValue, ok := value.([]interface{}); ok {
 			var tags []string
 			for _, tag := range newValue {
@@ -115,24 +290,13 @@ func (c *MarkdownConvert) Convert(mdStr string, data *TemplateData) (err error)
 			data.Keywords = fmt.Sprintf("%v", value)
 		}
 	}
-	if value, ok := metaData["Keywords"]; ok {
-		keywordsFunc(value)
-	} else if value, ok := metaData["keywords"]; ok {
-		keywordsFunc(value)
-	} else if value, ok := metaData["Tags"]; ok {
-		keywordsFunc(value)
-	} else if value, ok := metaData["tags"]; ok {
-		keywordsFunc(value)
-	}
 
-	if value, ok := metaData["Description"]; ok {
-		data.Description = fmt.Sprintf("%v", value)
-	} else if value, ok := metaData["description"]; ok {
-		data.Description = fmt.Sprintf("%v", value)
-	} else if value, ok := metaData["Summary"]; ok {
-		data.Description = fmt.Sprintf("%v", value)
-	} else if value, ok := metaData["summary"]; ok {
-		data.Description = fmt.Sprintf("%v", value)
+	if value := MetaString(metaData, "Description", "description", "Summary", "summary"); value != "" {
+		data.Description = value
+	}
+	data.Image = MetaString(metaData, "Image", "image", "Cover", "cover")
+	if data.Image == "" {
+		data.Image, _ = context.Get(firstImageKey).(string)
 	}
 	return nil
 }
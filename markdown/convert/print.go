@@ -0,0 +1,78 @@
+package convert
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+var printKey = parser.NewContextKey()
+
+// printPrefixAttr 文档节点上记录的id前缀, 脚注的id使用同样的前缀
+const printPrefixAttr = "data-print-prefix"
+
+// LinkResolver 转换文档中站点内的链接, 返回false时不修改
+type LinkResolver func(dest string) (string, bool)
+
+type printDoc struct {
+	prefix  string
+	resolve LinkResolver
+}
+
+// WithPrintDoc 文档作为打印页面的一部分渲染: 所有的id(标题、脚注)加上prefix, 文档内的锚点链接随之修改,
+// 其它链接由resolve转换(例如链接到同一页面中其它文档的锚点); 不插入目录
+func WithPrintDoc(prefix string, resolve LinkResolver) ConvertOption {
+	return func(pc parser.Context) {
+		pc.Set(printKey, &printDoc{prefix: prefix, resolve: resolve})
+	}
+}
+
+// printTransformer 在记录目录结构(优先级50)之前修改id和链接, 目录中是修改后的id
+type printTransformer struct{}
+
+func (printTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	p, ok := pc.Get(printKey).(*printDoc)
+	if !ok {
+		return
+	}
+	doc.SetAttributeString(printPrefixAttr, []byte(p.prefix))
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || n == doc {
+			return ast.WalkContinue, nil
+		}
+		if id, ok := n.AttributeString("id"); ok {
+			if value, ok := id.([]byte); ok {
+				n.SetAttributeString("id", append([]byte(p.prefix), value...))
+			}
+		}
+		switch n := n.(type) {
+		case *ast.Link:
+			n.Destination = p.link(n.Destination)
+		case *ast.Image:
+			n.Destination = p.link(n.Destination)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+func (p *printDoc) link(dest []byte) []byte {
+	if len(dest) > 1 && dest[0] == '#' {
+		return append([]byte("#"+p.prefix), dest[1:]...)
+	}
+	if p.resolve != nil {
+		if value, ok := p.resolve(string(dest)); ok {
+			return []byte(value)
+		}
+	}
+	return dest
+}
+
+// footnoteIDPrefix 打印页面中脚注的id前缀
+func footnoteIDPrefix(n ast.Node) []byte {
+	if doc := n.OwnerDocument(); doc != nil {
+		if prefix, ok := doc.AttributeString(printPrefixAttr); ok {
+			return prefix.([]byte)
+		}
+	}
+	return nil
+}
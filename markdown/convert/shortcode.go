@@ -0,0 +1,299 @@
+package convert
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// shortcodeLine 单独一行的短代码 {{< name args >}}、{{< name args />}} 或者结束标记 {{< /name >}}
+var shortcodeLine = regexp.MustCompile(`^\{\{<\s*(/?)([\w-]+)(.*?)(/?)\s*>\}\}\s*$`)
+
+// Shortcode 短代码的模板, paired的短代码需要结束标记 {{< /name >}}, 之间的内容按markdown渲染
+type Shortcode struct {
+	tmpl   *template.Template
+	paired bool
+}
+
+// Shortcodes 短代码的名称和模板
+type Shortcodes map[string]*Shortcode
+
+// TemplateFuncs 模板可以使用的sprig函数, 去掉了读取环境变量的 env 和 expandenv
+func TemplateFuncs() map[string]interface{} {
+	funcs := sprig.GenericFuncMap()
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	return funcs
+}
+
+// ParseShortcode 解析短代码的模板(html/template, 可以使用sprig函数), paired为是否需要结束标记
+func ParseShortcode(name, src string, paired bool) (*Shortcode, error) {
+	tmpl, err := template.New(name).Funcs(TemplateFuncs()).Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return &Shortcode{tmpl: tmpl, paired: paired}, nil
+}
+
+// DefaultShortcodes 内置的短代码
+var DefaultShortcodes = Shortcodes{}
+
+// builtinShortcode 内置短代码的模板, paired为是否需要结束标记
+type builtinShortcode struct {
+	src    string
+	paired bool
+}
+
+var builtinShortcodes = map[string]builtinShortcode{
+	// {{< video src="a.mp4" poster="a.jpg" width="640" >}}
+	"video": {src: `<video src="{{.Get "src" | default (.Get 0)}}" controls preload="metadata"` +
+		`{{with .Get "poster"}} poster="{{.}}"{{end}}{{with .Get "width"}} width="{{.}}"{{end}}` +
+		`{{if .Get "autoplay"}} autoplay muted{{end}}{{if .Get "loop"}} loop{{end}}></video>`},
+	// {{< details summary="标题" open=true >}} markdown {{< /details >}}
+	"details": {src: `<details{{if .Get "open"}} open{{end}}><summary>{{.Get "summary" | default (.Get 0) | default "Details"}}</summary>
+{{.Inner}}</details>`, paired: true},
+	// {{< figure src="a.png" caption="说明" link="https://..." >}}
+	"figure": {src: `<figure{{with .Get "class"}} class="{{.}}"{{end}}>` +
+		`{{with .Get "link"}}<a href="{{.}}">{{end}}` +
+		`<img src="{{.Get "src" | default (.Get 0)}}" alt="{{.Get "alt" | default (.Get "caption")}}" loading="lazy"` +
+		`{{with .Get "width"}} width="{{.}}"{{end}}{{with .Get "height"}} height="{{.}}"{{end}}>` +
+		`{{if .Get "link"}}</a>{{end}}` +
+		`{{with .Get "caption"}}<figcaption>{{.}}</figcaption>{{end}}</figure>`},
+	// {{< tabs >}} {{< tab "Go" >}} markdown {{< /tab >}} {{< /tabs >}}, 没有脚本时每个tab可以单独展开
+	"tabs": {src: `<div class="tabs">
+{{.Inner}}</div>`, paired: true},
+	"tab": {src: `<details class="tab"{{if eq .Index 0}} open{{end}}><summary>{{.Get "title" | default (.Get 0)}}</summary>
+{{.Inner}}</details>`, paired: true},
+}
+
+func init() {
+	for name, b := range builtinShortcodes {
+		sc, err := ParseShortcode(name, b.src, b.paired)
+		if err != nil {
+			panic(err)
+		}
+		DefaultShortcodes[name] = sc
+	}
+}
+
+// ShortcodeData 短代码模板的数据
+type ShortcodeData struct {
+	Name   string            `remark:"短代码名称"`
+	Params map[string]string `remark:"命名参数 key=value"`
+	Args   []string          `remark:"位置参数"`
+	Inner  template.HTML     `remark:"开始和结束标记之间的内容(渲染后的html)"`
+	Index  int               `remark:"在父短代码中同名短代码的序号, 从0开始"`
+	Parent string            `remark:"父短代码的名称"`
+}
+
+// Get 返回命名参数(字符串)或者位置参数(数字), 不存在时返回空
+func (d *ShortcodeData) Get(key interface{}) string {
+	switch k := key.(type) {
+	case string:
+		return d.Params[k]
+	case int:
+		if k >= 0 && k < len(d.Args) {
+			return d.Args[k]
+		}
+	}
+	return ""
+}
+
+// shortcodesKey 在parser.Context中保存本次转换使用的短代码
+var shortcodesKey = parser.NewContextKey()
+
+// WithShortcodes 使用指定的短代码转换, 默认为 DefaultShortcodes
+func WithShortcodes(shortcodes Shortcodes) ConvertOption {
+	return func(pc parser.Context) {
+		pc.Set(shortcodesKey, shortcodes)
+	}
+}
+
+// KindShortcode 短代码节点
+var KindShortcode = ast.NewNodeKind("Shortcode")
+
+// ShortcodeNode 短代码, 有结束标记时子节点为之间的内容
+type ShortcodeNode struct {
+	ast.BaseBlock
+	Name   string
+	Params map[string]string
+	Args   []string
+
+	shortcode *Shortcode
+	closed    bool
+}
+
+func (n *ShortcodeNode) Kind() ast.NodeKind {
+	return KindShortcode
+}
+
+func (n *ShortcodeNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Name": n.Name}, nil)
+}
+
+// shortcodeParser 解析单独一行的短代码, 没有定义的短代码按普通文本处理
+type shortcodeParser struct{}
+
+func (p *shortcodeParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+func (p *shortcodeParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	m := shortcodeLine.FindSubmatch(util.TrimRightSpace(util.TrimLeftSpace(line)))
+	if m == nil || len(m[1]) > 0 {
+		return nil, parser.NoChildren
+	}
+	shortcodes, _ := pc.Get(shortcodesKey).(Shortcodes)
+	if shortcodes == nil {
+		shortcodes = DefaultShortcodes
+	}
+	sc, ok := shortcodes[string(m[2])]
+	if !ok {
+		return nil, parser.NoChildren
+	}
+	node := &ShortcodeNode{Name: string(m[2]), shortcode: sc}
+	node.Params, node.Args = shortcodeArgs(string(m[3]))
+	reader.Advance(segment.Len() - 1)
+	if !sc.paired || len(m[4]) > 0 {
+		node.closed = true
+		return node, parser.NoChildren
+	}
+	return node, parser.HasChildren
+}
+
+func (p *shortcodeParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	n := node.(*ShortcodeNode)
+	if n.closed {
+		return parser.Close
+	}
+	line, segment := reader.PeekLine()
+	if m := shortcodeLine.FindSubmatch(util.TrimRightSpace(util.TrimLeftSpace(line))); m != nil && len(m[1]) > 0 && string(m[2]) == n.Name {
+		reader.Advance(segment.Len() - 1)
+		n.closed = true
+		return parser.Close
+	}
+	return parser.Continue | parser.HasChildren
+}
+
+func (p *shortcodeParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p *shortcodeParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (p *shortcodeParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// shortcodeArgs 解析参数: key=value、key="value"、"位置参数"、位置参数
+func shortcodeArgs(s string) (map[string]string, []string) {
+	params := map[string]string{}
+	var args []string
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		key := ""
+		if i := strings.IndexAny(s, "= \t\"`"); i > 0 && s[i] == '=' {
+			key, s = s[:i], s[i+1:]
+		}
+		var value string
+		value, s = shortcodeValue(s)
+		if key != "" {
+			params[key] = value
+		} else {
+			args = append(args, value)
+		}
+	}
+	return params, args
+}
+
+// shortcodeValue 读取一个参数值, 支持 "..." 和 `...`
+func shortcodeValue(s string) (value, rest string) {
+	if s != "" && (s[0] == '"' || s[0] == '`') {
+		quote := s[0]
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' && quote == '"' {
+				i++
+				continue
+			}
+			if s[i] == quote {
+				if v, err := strconv.Unquote(s[:i+1]); err == nil {
+					return v, s[i+1:]
+				}
+				return s[1:i], s[i+1:]
+			}
+		}
+		return s[1:], ""
+	}
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// shortcodeRenderer 使用模板渲染短代码, 之间的内容先渲染为html作为 .Inner
+type shortcodeRenderer struct {
+	md goldmark.Markdown
+}
+
+func (r *shortcodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindShortcode, r.render)
+}
+
+func (r *shortcodeRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ShortcodeNode)
+	data := &ShortcodeData{Name: n.Name, Params: n.Params, Args: n.Args}
+	if parent, ok := n.Parent().(*ShortcodeNode); ok {
+		data.Parent = parent.Name
+	}
+	for s := n.PreviousSibling(); s != nil; s = s.PreviousSibling() {
+		if sibling, ok := s.(*ShortcodeNode); ok && sibling.Name == n.Name {
+			data.Index++
+		}
+	}
+	if n.HasChildren() {
+		var inner bytes.Buffer
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			if err := r.md.Renderer().Render(&inner, source, c); err != nil {
+				return ast.WalkStop, err
+			}
+		}
+		data.Inner = template.HTML(inner.String())
+	}
+	var buf bytes.Buffer
+	if err := n.shortcode.tmpl.Execute(&buf, data); err != nil {
+		fmt.Fprintf(w, "<p class=\"shortcode-error\">%s</p>\n", template.HTMLEscapeString(err.Error()))
+		return ast.WalkSkipChildren, nil
+	}
+	_, _ = w.Write(buf.Bytes())
+	_ = w.WriteByte('\n')
+	return ast.WalkSkipChildren, nil
+}
+
+// shortcodeExtension Hugo风格的短代码
+type shortcodeExtension struct{}
+
+// ShortcodeExtension 单独一行的 {{< name args >}} 使用短代码模板渲染
+var ShortcodeExtension = &shortcodeExtension{}
+
+func (e *shortcodeExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithBlockParsers(
+		util.Prioritized(&shortcodeParser{}, 90),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&shortcodeRenderer{md: m}, 100),
+	))
+}
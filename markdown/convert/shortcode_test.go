@@ -0,0 +1,55 @@
+package convert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseShortcodeFuncs(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr bool
+	}{
+		{`{{upper "a"}}`, false},
+		{`{{env "HOME"}}`, true},
+		{`{{expandenv "$HOME"}}`, true},
+	}
+	for _, tt := range tests {
+		_, err := ParseShortcode("test", tt.src, false)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseShortcode(%q) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+		}
+	}
+}
+
+func TestShortcodePaired(t *testing.T) {
+	box, _ := ParseShortcode("box", `<div class="box">{{.Inner}}</div>`, true)
+	// 没有声明paired时, 即使模板中有 .Inner 也不需要结束标记
+	note, _ := ParseShortcode("note", `<p class="note">{{.Get 0}}{{.Inner}}</p>`, false)
+	shortcodes := Shortcodes{"box": box, "note": note}
+
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"paired", "{{< box >}}\n**text**\n{{< /box >}}\n", `<div class="box"><p><strong>text</strong></p>`},
+		{"self-closing", "{{< box />}}\n\nafter\n", `<div class="box"></div>`},
+		{"unpaired", "{{< note \"hi\" >}}\n**text**\n", `<p class="note">hi</p>`},
+	}
+	c := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &TemplateData{}
+			if err := c.Convert(tt.src, data, WithShortcodes(shortcodes)); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(data.MdHtml, tt.want) {
+				t.Errorf("got %q, want %q", data.MdHtml, tt.want)
+			}
+			if tt.name == "unpaired" && !strings.Contains(data.MdHtml, "<strong>text</strong>") {
+				t.Errorf("content after an unpaired shortcode is missing: %q", data.MdHtml)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package convert
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// SidebarItem 侧边栏(_sidebar.md)中的一项
+type SidebarItem struct {
+	Title string
+	// 链接, 没有链接的项为分组
+	Link  string
+	Items []SidebarItem
+}
+
+var sidebarParser = goldmark.New().Parser()
+
+// ParseSidebar 解析docsify风格的 _sidebar.md: 嵌套的列表, 每一项为链接([安装](guide/install.md))
+// 或者分组的标题, 列表以外的内容忽略
+func ParseSidebar(src []byte) []SidebarItem {
+	src = []byte(stripFrontMatter(string(src)))
+	doc := sidebarParser.Parse(text.NewReader(src))
+	var items []SidebarItem
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		if list, ok := n.(*ast.List); ok {
+			items = append(items, sidebarList(list, src)...)
+		}
+	}
+	return items
+}
+
+func sidebarList(list *ast.List, source []byte) []SidebarItem {
+	var items []SidebarItem
+	for li := list.FirstChild(); li != nil; li = li.NextSibling() {
+		var item SidebarItem
+		for c := li.FirstChild(); c != nil; c = c.NextSibling() {
+			switch n := c.(type) {
+			case *ast.List:
+				item.Items = append(item.Items, sidebarList(n, source)...)
+			case *ast.TextBlock, *ast.Paragraph:
+				if item.Title != "" || item.Link != "" {
+					continue
+				}
+				item.Title = strings.TrimSpace(inlineText(n, source))
+				_ = ast.Walk(n, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+					if link, ok := node.(*ast.Link); ok && entering {
+						item.Title = strings.TrimSpace(inlineText(link, source))
+						item.Link = string(link.Destination)
+						return ast.WalkStop, nil
+					}
+					return ast.WalkContinue, nil
+				})
+			}
+		}
+		if item.Title != "" || item.Link != "" || len(item.Items) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// inlineText 返回行内节点的文本
+func inlineText(n ast.Node, source []byte) string {
+	var b bytes.Buffer
+	_ = ast.Walk(n, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := node.(type) {
+		case *ast.Text:
+			b.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSidebar(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []SidebarItem
+	}{
+		{
+			"links",
+			"* [Home](/)\n* [Install](guide/install.md)\n",
+			[]SidebarItem{{Title: "Home", Link: "/"}, {Title: "Install", Link: "guide/install.md"}},
+		},
+		{
+			"groups",
+			"- Guide\n  - [Install](guide/install.md)\n  - [**Usage**](guide/usage.md)\n- [FAQ](faq.md)\n",
+			[]SidebarItem{
+				{Title: "Guide", Items: []SidebarItem{
+					{Title: "Install", Link: "guide/install.md"},
+					{Title: "Usage", Link: "guide/usage.md"},
+				}},
+				{Title: "FAQ", Link: "faq.md"},
+			},
+		},
+		{
+			"text around the link",
+			"* see [API](api.md) first\n",
+			[]SidebarItem{{Title: "API", Link: "api.md"}},
+		},
+		{
+			"front matter and paragraphs are ignored",
+			"---\ntitle: Sidebar\n---\n# Docs\n\nintro\n\n1. [One](one.md)\n",
+			[]SidebarItem{{Title: "One", Link: "one.md"}},
+		},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSidebar([]byte(tt.src)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSidebar() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package convert
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var slidesKey = parser.NewContextKey()
+
+// WithSlides 把文档分成幻灯片: 有 --- 分隔线时按分隔线, 否则按一级和二级标题
+func WithSlides() ConvertOption {
+	return func(pc parser.Context) {
+		pc.Set(slidesKey, true)
+	}
+}
+
+// KindSlide 幻灯片节点
+var KindSlide = ast.NewNodeKind("Slide")
+
+// Slide 一页幻灯片, 子节点为该页的内容
+type Slide struct {
+	ast.BaseBlock
+}
+
+func (n *Slide) Kind() ast.NodeKind {
+	return KindSlide
+}
+
+func (n *Slide) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// KindSlideNotes 演讲者备注节点
+var KindSlideNotes = ast.NewNodeKind("SlideNotes")
+
+// SlideNotes 幻灯片中 Note: 开始的段落及其后面的内容
+type SlideNotes struct {
+	ast.BaseBlock
+}
+
+func (n *SlideNotes) Kind() ast.NodeKind {
+	return KindSlideNotes
+}
+
+func (n *SlideNotes) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// 演讲者备注的开始
+var notePrefixes = [][]byte{[]byte("Note:"), []byte("Notes:"), []byte("note:"), []byte("notes:")}
+
+// slidesTransformer 把文档的顶层节点分组为幻灯片, 在插入目录和处理mermaid(优先级100)之前执行
+type slidesTransformer struct{}
+
+func (slidesTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	if enabled, _ := pc.Get(slidesKey).(bool); !enabled {
+		return
+	}
+	source := reader.Source()
+	byBreak := false
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		if n.Kind() == ast.KindThematicBreak {
+			byBreak = true
+			break
+		}
+	}
+	var slides []*Slide
+	var current *Slide
+	for n := doc.FirstChild(); n != nil; {
+		next := n.NextSibling()
+		doc.RemoveChild(doc, n)
+		switch {
+		case byBreak && n.Kind() == ast.KindThematicBreak:
+			current = nil
+			n = next
+			continue
+		case !byBreak && isSlideHeading(n):
+			current = nil
+		}
+		if current == nil {
+			current = &Slide{}
+			slides = append(slides, current)
+		}
+		current.AppendChild(current, n)
+		n = next
+	}
+	for _, slide := range slides {
+		splitNotes(slide, source)
+		doc.AppendChild(doc, slide)
+	}
+}
+
+func isSlideHeading(n ast.Node) bool {
+	h, ok := n.(*ast.Heading)
+	return ok && h.Level <= 2
+}
+
+// splitNotes 把 Note: 开始的段落和后面的内容移到演讲者备注中, 并去掉 Note:
+func splitNotes(slide *Slide, source []byte) {
+	var notes *SlideNotes
+	for n := slide.FirstChild(); n != nil; {
+		next := n.NextSibling()
+		if notes == nil {
+			para, ok := n.(*ast.Paragraph)
+			if !ok || !trimNotePrefix(para, source) {
+				n = next
+				continue
+			}
+			notes = &SlideNotes{}
+			if !para.HasChildren() {
+				slide.RemoveChild(slide, para)
+				n = next
+				continue
+			}
+		}
+		slide.RemoveChild(slide, n)
+		notes.AppendChild(notes, n)
+		n = next
+	}
+	if notes != nil {
+		slide.AppendChild(slide, notes)
+	}
+}
+
+// trimNotePrefix 段落以 Note: 开始时去掉 Note: 并返回true.
+// 冒号可能触发自动链接的解析, Note: 会被分成多个文本节点
+func trimNotePrefix(para *ast.Paragraph, source []byte) bool {
+	var texts []*ast.Text
+	var value []byte
+	for n := para.FirstChild(); n != nil; n = n.NextSibling() {
+		t, ok := n.(*ast.Text)
+		if !ok {
+			break
+		}
+		texts = append(texts, t)
+		value = append(value, t.Segment.Value(source)...)
+		if t.SoftLineBreak() || t.HardLineBreak() {
+			break
+		}
+	}
+	for _, prefix := range notePrefixes {
+		if !bytes.HasPrefix(value, prefix) {
+			continue
+		}
+		trim := len(prefix)
+		for _, t := range texts {
+			if trim > 0 {
+				n := min(trim, t.Segment.Len())
+				t.Segment = t.Segment.WithStart(t.Segment.Start + n)
+				trim -= n
+			}
+			t.Segment = t.Segment.TrimLeftSpace(source)
+			if t.Segment.Len() > 0 {
+				break
+			}
+			para.RemoveChild(para, t)
+		}
+		return true
+	}
+	return false
+}
+
+// slidesRenderer 幻灯片渲染为 <section class="slide">, 备注渲染为 <aside class="notes">
+type slidesRenderer struct{}
+
+func (r slidesRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindSlide, r.renderSlide)
+	reg.Register(KindSlideNotes, r.renderNotes)
+}
+
+func (r slidesRenderer) renderSlide(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<section class=\"slide\">\n")
+	} else {
+		_, _ = w.WriteString("</section>\n")
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r slidesRenderer) renderNotes(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<aside class=\"notes\">\n")
+	} else {
+		_, _ = w.WriteString("</aside>\n")
+	}
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,105 @@
+package convert
+
+import (
+	"math"
+	"unicode"
+
+	katex "github.com/kingreatwill/goldmark-katex"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// 默认的阅读速度
+const (
+	// DefaultWordsPerMinute 英文等按空白分隔的文字每分钟阅读的单词数
+	DefaultWordsPerMinute = 200
+	// DefaultCJKCharsPerMinute 中日韩文字每分钟阅读的字数
+	DefaultCJKCharsPerMinute = 400
+)
+
+// DocStats 文档的字数统计, 不包含代码块、公式、html和元数据(front matter)
+type DocStats struct {
+	// 单词数, 中日韩文字每个字算一个
+	Words int
+	// 字符数(不包含空白)
+	Chars int
+	// 中日韩文字的字数
+	CJKChars int
+}
+
+// ReadingTime 预计阅读时间(分钟), 有内容时至少为1
+func (s DocStats) ReadingTime(wordsPerMinute, cjkCharsPerMinute int) int {
+	if s.Words == 0 {
+		return 0
+	}
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	if cjkCharsPerMinute <= 0 {
+		cjkCharsPerMinute = DefaultCJKCharsPerMinute
+	}
+	minutes := float64(s.Words-s.CJKChars)/float64(wordsPerMinute) + float64(s.CJKChars)/float64(cjkCharsPerMinute)
+	return int(math.Max(1, math.Ceil(minutes)))
+}
+
+// count 统计一段文字: 中日韩文字按字计数, 其它文字按空白和标点分隔的单词计数
+func (s *DocStats) count(value []byte) {
+	inWord := false
+	for _, r := range string(value) {
+		switch {
+		case unicode.IsSpace(r):
+			inWord = false
+			continue
+		case isCJK(r):
+			s.Words++
+			s.CJKChars++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) || r == '\'' || r == '’' || r == '-' || r == '_':
+			// don't、e-mail 算一个单词
+			if !inWord && r != '\'' && r != '’' && r != '-' && r != '_' {
+				s.Words++
+				inWord = true
+			}
+		default:
+			inWord = false
+		}
+		s.Chars++
+	}
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+var statsKey = parser.NewContextKey()
+
+// statsCollector 在插入目录之前统计文档的字数
+type statsCollector struct{}
+
+func (statsCollector) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	stats := &DocStats{}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock, *ast.RawHTML, *katex.MathBlock, *katex.InlineMath:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			stats.count(node.Segment.Value(source))
+		case *ast.String:
+			stats.count(node.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	pc.Set(statsKey, stats)
+}
+
+func getStats(pc parser.Context) DocStats {
+	if stats, ok := pc.Get(statsKey).(*DocStats); ok {
+		return *stats
+	}
+	return DocStats{}
+}
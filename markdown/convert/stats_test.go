@@ -0,0 +1,91 @@
+package convert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertStats(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		words int
+		chars int
+	}{
+		{"empty", "", 0, 0},
+		{"latin", "Hello, world! It's an e-mail test.", 6, 29},
+		{"cjk", "你好世界", 4, 4},
+		{"mixed", "使用 Go 语言编写 2 个 web 服务", 12, 15},
+		{"japanese and korean", "こんにちは 안녕", 7, 7},
+		{"front matter", "---\ntitle: Many words in the title\n---\none two\n", 2, 6},
+		{"fenced code", "one\n\n```go\nfunc main() { fmt.Println(\"x\") }\n```\n\ntwo\n", 2, 6},
+		{"indented code", "one\n\n    code block here\n\ntwo\n", 2, 6},
+		// 行内代码是正文的一部分
+		{"inline code", "run `go test` now\n", 4, 12},
+		{"html", "one <span>two</span>\n\n<div>\nthree four\n</div>\n", 2, 6},
+		{"math", "area $\\pi r^2$ is\n\n$$\nx = y + z\n$$\n", 2, 6},
+		{"formatting", "# Title\n\n*one* **two** [three](http://example.com/a-b-c)\n", 4, 16},
+	}
+	c := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &TemplateData{}
+			if err := c.Convert(tt.src, data); err != nil {
+				t.Fatal(err)
+			}
+			if data.WordCount != tt.words || data.CharCount != tt.chars {
+				t.Errorf("words, chars = %d, %d, want %d, %d", data.WordCount, data.CharCount, tt.words, tt.chars)
+			}
+		})
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	tests := []struct {
+		name           string
+		stats          DocStats
+		wpm, cjkPerMin int
+		want           int
+	}{
+		{"empty", DocStats{}, 0, 0, 0},
+		{"at least a minute", DocStats{Words: 1}, 0, 0, 1},
+		{"default speed", DocStats{Words: 400}, 0, 0, 2},
+		{"rounded up", DocStats{Words: 401}, 0, 0, 3},
+		{"custom speed", DocStats{Words: 300}, 100, 0, 3},
+		{"cjk default", DocStats{Words: 800, CJKChars: 800}, 0, 0, 2},
+		{"cjk custom", DocStats{Words: 800, CJKChars: 800}, 0, 200, 4},
+		// 200/200 + 400/400 = 2
+		{"mixed", DocStats{Words: 600, CJKChars: 400}, 0, 0, 2},
+		{"mixed custom", DocStats{Words: 600, CJKChars: 400}, 50, 100, 8},
+		{"negative speed", DocStats{Words: 200}, -1, -1, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.stats.ReadingTime(tt.wpm, tt.cjkPerMin); got != tt.want {
+			t.Errorf("%s: ReadingTime(%d, %d) = %d, want %d", tt.name, tt.wpm, tt.cjkPerMin, got, tt.want)
+		}
+	}
+}
+
+func TestConvertReadingSpeed(t *testing.T) {
+	src := strings.Repeat("word ", 250) + "\n\n" + strings.Repeat("字", 250) + "\n"
+	tests := []struct {
+		wpm, cjkPerMin int
+		want           int
+	}{
+		// 250/200 + 250/400 = 1.875
+		{0, 0, 2},
+		// 250/500 + 250/500 = 1
+		{500, 500, 1},
+		// 250/100 + 250/100 = 5
+		{100, 100, 5},
+	}
+	for _, tt := range tests {
+		data := &TemplateData{}
+		if err := New(WithReadingSpeed(tt.wpm, tt.cjkPerMin)).Convert(src, data); err != nil {
+			t.Fatal(err)
+		}
+		if data.WordCount != 500 || data.ReadingTime != tt.want {
+			t.Errorf("speed %d, %d: words = %d, reading time = %d, want 500, %d", tt.wpm, tt.cjkPerMin, data.WordCount, data.ReadingTime, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package convert
+
+import (
+	"fmt"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"go.abhg.dev/goldmark/toc"
+)
+
+// TocItem 目录(Table of Contents)中的一项
+type TocItem struct {
+	Title string    `json:"title" remark:"标题"`
+	ID    string    `json:"id,omitempty" remark:"标题id,可作为锚点"`
+	Items []TocItem `json:"items,omitempty" remark:"子标题"`
+}
+
+var tocKey = parser.NewContextKey()
+
+// tocCollector 在 tocInserter 插入目录之前记录文档的目录结构
+type tocCollector struct{}
+
+func (tocCollector) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	if t, err := toc.Inspect(doc, reader.Source()); err == nil {
+		pc.Set(tocKey, t)
+	}
+}
+
+// tocInserter 在文档开头插入目录, 打印页面中的文档使用合并的目录, 不插入
+type tocInserter struct {
+	toc.Transformer
+}
+
+func (t *tocInserter) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	if _, ok := pc.Get(printKey).(*printDoc); ok {
+		return
+	}
+	t.Transformer.Transform(doc, reader, pc)
+}
+
+func getToc(pc parser.Context) []TocItem {
+	t, ok := pc.Get(tocKey).(*toc.TOC)
+	if !ok {
+		return nil
+	}
+	return tocItems(t.Items)
+}
+
+func tocItems(items toc.Items) []TocItem {
+	var result []TocItem
+	for _, item := range items {
+		result = append(result, TocItem{
+			Title: string(item.Title),
+			ID:    string(item.ID),
+			Items: tocItems(item.Items),
+		})
+	}
+	return result
+}
+
+// normalizeMeta 将yaml解析出的 map[interface{}]interface{} 转换为可以json序列化的 map[string]interface{}
+func normalizeMeta(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprintf("%v", key)] = normalizeMeta(val)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[key] = normalizeMeta(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, val := range v {
+			s[i] = normalizeMeta(val)
+		}
+		return s
+	}
+	return value
+}
@@ -0,0 +1,29 @@
+package markdown
+
+import (
+	"path"
+	"strings"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+// CSVTables 在页面模板中把 .csv 和 .tsv 文件渲染为可以排序的表格, ?format=markdown 返回原始文件.
+// markdown中的 ```csv 和 ```tsv 代码块总是渲染为表格.
+type CSVTables struct {
+	// 表格最多显示的行数(不包括表头), 其余的行只显示行数. Default: 1000
+	MaxRows int `json:"max_rows,omitempty"`
+}
+
+// isTableFile 是否需要渲染为表格的csv/tsv文件
+func (md *Markdown) isTableFile(name string) bool {
+	if md.CSV == nil {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".csv" || ext == ".tsv"
+}
+
+// tableMarkdown 把csv/tsv文件转换为markdown的表格代码块
+func (md *Markdown) tableMarkdown(name string, content []byte) (string, error) {
+	return convert.CSVMarkdown(content, strings.EqualFold(path.Ext(name), ".tsv"), md.CSV.MaxRows)
+}
@@ -0,0 +1,153 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"go.uber.org/zap"
+)
+
+// errorFileName 没有对应状态码的错误页面(_404.md)时使用的错误页面
+const errorFileName = "_error.md"
+
+// anyStatus ErrorPages 中其它状态码使用的错误页面
+const anyStatus = "*"
+
+// validErrorPages 检查 ErrorPages: key 为 4xx/5xx 状态码或 *, 文件名不能包含目录
+func validErrorPages(pages map[string]string) error {
+	for status, name := range pages {
+		if status != anyStatus {
+			if code, err := strconv.Atoi(status); err != nil || code < 400 || code > 599 {
+				return fmt.Errorf("bad error_pages status '%s'", status)
+			}
+		}
+		if name == "" || strings.Contains(name, "/") || name == "." || name == ".." {
+			return fmt.Errorf("bad error_pages file name '%s' for %s", name, status)
+		}
+	}
+	return nil
+}
+
+// errorPageNames 按顺序返回状态码对应的错误页面文件名, 默认为 _<status>.md 和 _error.md
+func (md *Markdown) errorPageNames(status int) []string {
+	code := strconv.Itoa(status)
+	names := []string{"_" + code + ".md", errorFileName}
+	if name, ok := md.ErrorPages[code]; ok {
+		names[0] = name
+	}
+	if name, ok := md.ErrorPages[anyStatus]; ok {
+		names[1] = name
+	}
+	return names
+}
+
+// errorStatus 按错误的类型返回状态码: 文件不存在404, 没有权限403, 其它500
+func errorStatus(err error) int {
+	var he caddyhttp.HandlerError
+	switch {
+	case errors.As(err, &he) && he.StatusCode != 0:
+		return he.StatusCode
+	case errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// statusError 按错误的类型转换为 caddyhttp.HandlerError
+func statusError(err error) error {
+	return caddyhttp.Error(errorStatus(err), err)
+}
+
+// findErrorPage 从请求的目录开始向上查找 errorPageNames 中的文件, 返回错误页面的路径和文件名.
+// 当前用户没有权限的目录中的错误页面不使用
+func (md *Markdown) findErrorPage(r *http.Request, fsys fs.FS, root string, status int) (string, string, bool) {
+	urlDir := path.Clean("/" + r.URL.Path)
+	if r.URL.Path != "" && r.URL.Path[len(r.URL.Path)-1] != '/' {
+		urlDir = path.Dir(urlDir)
+	}
+	lang := languageFromRequest(r)
+	names := md.errorPageNames(status)
+	for {
+		if md.canView(r, urlDir, nil) {
+			dir := caddyhttp.SanitizedPathJoin(root, urlDir)
+			for _, name := range names {
+				filename := md.languageFile(fsys, path.Join(dir, name), lang)
+				if info, err := fs.Stat(fsys, filename); err == nil && !info.IsDir() {
+					return path.Join(urlDir, path.Base(filename)), filename, true
+				}
+			}
+		}
+		if urlDir == "/" {
+			return "", "", false
+		}
+		urlDir = path.Dir(urlDir)
+	}
+}
+
+// errorPlaceholders 错误页面中只替换这两个占位符, 不展开环境变量、请求头等其它占位符
+func errorPlaceholders(status int, statusText string) *strings.Replacer {
+	return strings.NewReplacer(
+		"{http.error.status_code}", strconv.Itoa(status),
+		"{http.error.status_text}", statusText,
+	)
+}
+
+// serveError 使用站点模板渲染错误页面, 错误页面中可以使用 {http.error.status_code} {http.error.status_text} 占位符;
+// 没有错误页面或者渲染失败时返回原来的错误
+func (md *Markdown) serveError(w http.ResponseWriter, r *http.Request, err error) error {
+	status := errorStatus(err)
+	err = caddyhttp.Error(status, err)
+	// 只给浏览器返回错误页面, 图片、脚本和API请求返回原来的错误
+	if status < 400 || (r.Method != http.MethodGet && r.Method != http.MethodHead) || !strings.Contains(r.Header.Get("Accept"), "text/html") {
+		return err
+	}
+	fsys, fsErr := md.getFS(r)
+	if fsErr != nil {
+		return err
+	}
+	urlPath, filename, ok := md.findErrorPage(r, fsys, md.getRoot(r), status)
+	if !ok {
+		return err
+	}
+	content, readErr := fs.ReadFile(fsys, filename)
+	if readErr != nil {
+		return err
+	}
+
+	statusText := http.StatusText(status)
+	repl := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
+	repl.Set("http.error.status_code", status)
+	repl.Set("http.error.status_text", statusText)
+
+	// 目录列表和导航树使用错误页面所在的目录
+	page := r.Clone(r.Context())
+	page.URL.Path = urlPath
+	data, renderErr := md.renderData(page, errorPlaceholders(status, statusText).Replace(string(content)))
+	if renderErr != nil {
+		md.logger.Error("error page", zap.String("path", filename), zap.Error(renderErr))
+		return err
+	}
+	data.Status, data.StatusText = status, statusText
+	if convert.MetaString(data.Meta, "Title", "title") == "" {
+		data.Title = fmt.Sprintf("%d %s", status, statusText)
+	}
+	// 错误页面不是当前路径的内容
+	data.Canonical, data.MetaTags, data.JSONLD, data.SEOHead = "", nil, "", ""
+
+	// 后续handler设置的响应头不适用于错误页面
+	for _, h := range []string{"Etag", "Last-Modified", "Content-Length", "Content-Type", "Accept-Ranges", "Content-Encoding"} {
+		w.Header().Del(h)
+	}
+	md.logger.Debug("serving error page", zap.String("path", r.URL.Path), zap.Int("status", status), zap.Error(err))
+	return md.writeHTML(w, page, status, data, "")
+}
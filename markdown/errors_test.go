@@ -0,0 +1,104 @@
+package markdown
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestErrorPagePlaceholders(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md": "# Home\n",
+		"_error.md": "# Oops\n\ncode={http.error.status_code} text={http.error.status_text} env={env.HOME} header={http.request.header.X-Secret}\n",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) { md.Root = root })
+	r := newTestRequest(root, "/missing.md", "")
+	r.Header.Set("Accept", "text/html")
+	r.Header.Set("X-Secret", "token")
+
+	rec := serveTest(t, md, root, r)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"code=404", "text=Not Found", "env={env.HOME}", "header={http.request.header.X-Secret}"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+	if strings.Contains(body, "token") {
+		t.Error("the request header is expanded in the error page")
+	}
+}
+
+func TestErrorPageNames(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":        "# Home\n",
+		"not-found.md":     "# Custom 404\n",
+		"oops.md":          "# Custom error\n",
+		"_404.md":          "# Default 404\n",
+		"_error.md":        "# Default error\n",
+		"docs/README.md":   "# Docs\n",
+		"docs/_error.md":   "# Docs error\n",
+		"docs/private.md":  "---\naccess: [ann]\n---\n# Private\n",
+		"other/README.md":  "# Other\n",
+		"other/oops.md":    "# Other error\n",
+		"other/private.md": "---\naccess: [ann]\n---\n# Private\n",
+	})
+	tests := []struct {
+		name   string
+		pages  map[string]string
+		target string
+		status int
+		want   string
+	}{
+		{"default status", nil, "/missing.md", http.StatusNotFound, "Default 404"},
+		{"default fallback", nil, "/docs/private.md", http.StatusForbidden, "Docs error"},
+		{"status", map[string]string{"404": "not-found.md"}, "/missing.md", http.StatusNotFound, "Custom 404"},
+		// 没有指定的仍使用默认的文件名
+		{"status keeps the default fallback", map[string]string{"404": "not-found.md"}, "/docs/private.md", http.StatusForbidden, "Docs error"},
+		{"fallback", map[string]string{"*": "oops.md"}, "/other/private.md", http.StatusForbidden, "Other error"},
+		{"fallback keeps the default status", map[string]string{"*": "oops.md"}, "/missing.md", http.StatusNotFound, "Default 404"},
+		{"fallback after status", map[string]string{"404": "none.md", "*": "oops.md"}, "/missing.md", http.StatusNotFound, "Custom error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newTestMarkdown(t, func(md *Markdown) {
+				md.Root = root
+				md.ErrorPages = tt.pages
+				md.Access = &Access{DenyStatus: http.StatusForbidden}
+			})
+			r := newTestRequest(root, tt.target, "")
+			r.Header.Set("Accept", "text/html")
+			rec := serveTest(t, md, root, r)
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("%q not found in %q", tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestValidErrorPages(t *testing.T) {
+	tests := []struct {
+		pages map[string]string
+		ok    bool
+	}{
+		{nil, true},
+		{map[string]string{"404": "not-found.md", "500": "_500.md", "*": "oops.md"}, true},
+		{map[string]string{"200": "ok.md"}, false},
+		{map[string]string{"4xx": "client.md"}, false},
+		{map[string]string{"404": ""}, false},
+		{map[string]string{"404": "errors/404.md"}, false},
+		{map[string]string{"*": ".."}, false},
+	}
+	for _, tt := range tests {
+		if err := validErrorPages(tt.pages); (err == nil) != tt.ok {
+			t.Errorf("validErrorPages(%v) = %v, want ok %v", tt.pages, err, tt.ok)
+		}
+	}
+}
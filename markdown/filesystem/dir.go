@@ -0,0 +1,87 @@
+package filesystem
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/caddyconfig/caddyfile"
+)
+
+func init() {
+	caddy.RegisterModule(DirFS{})
+}
+
+// DirFS 以本地目录为根的文件系统. Syntax:
+//
+//	filesystem <name> dir <root>
+type DirFS struct {
+	// 目录路径, 支持全局占位符(例如 {env.NOTES_DIR})
+	Root string `json:"root,omitempty"`
+
+	fs.FS `json:"-"`
+	root  string
+}
+
+func (DirFS) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "caddy.fs.dir",
+		New: func() caddy.Module { return new(DirFS) },
+	}
+}
+
+// Provision sets up the module. #caddy.Provisioner
+func (d *DirFS) Provision(ctx caddy.Context) error {
+	root := caddy.NewReplacer().ReplaceAll(d.Root, "")
+	if root == "" {
+		root = "."
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("dir filesystem root %s: %v", root, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return fmt.Errorf("dir filesystem root %s: %v", abs, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("dir filesystem root %s is not a directory", abs)
+	}
+	d.root = abs
+	d.FS = os.DirFS(abs)
+	return nil
+}
+
+// LocalPath 返回文件在磁盘上的路径
+func (d *DirFS) LocalPath(name string) string {
+	return filepath.Join(d.root, filepath.FromSlash(name))
+}
+
+func (d *DirFS) Open(name string) (fs.File, error)          { return open(d, d.FS, name) }
+func (d *DirFS) Stat(name string) (fs.FileInfo, error)      { return fs.Stat(d.FS, name) }
+func (d *DirFS) ReadDir(name string) ([]fs.DirEntry, error) { return fs.ReadDir(d.FS, name) }
+func (d *DirFS) ReadFile(name string) ([]byte, error)       { return fs.ReadFile(d.FS, name) }
+
+// UnmarshalCaddyfile sets up the module from Caddyfile tokens.
+func (d *DirFS) UnmarshalCaddyfile(h *caddyfile.Dispenser) error {
+	h.Next() // consume module name
+	if !h.Args(&d.Root) {
+		return h.ArgErr()
+	}
+	if h.NextArg() {
+		return h.ArgErr()
+	}
+	return nil
+}
+
+// Interface guards
+var (
+	_ caddy.Provisioner     = (*DirFS)(nil)
+	_ caddyfile.Unmarshaler = (*DirFS)(nil)
+	_ fs.StatFS             = (*DirFS)(nil)
+	_ fs.ReadDirFS          = (*DirFS)(nil)
+	_ fs.ReadFileFS         = (*DirFS)(nil)
+	_ LocalFS               = (*DirFS)(nil)
+)
@@ -0,0 +1,85 @@
+// Package filesystem 提供可以通过 Caddy 全局 filesystem 选项注册的文件系统模块,
+// markdown 和 file_server 都可以通过 fs 选项使用它们:
+//
+//	{
+//	    filesystem notes git /srv/notes.git {
+//	        ref published
+//	    }
+//	}
+//	:2018 {
+//	    fs notes
+//	    root .
+//	    markdown
+//	    file_server
+//	}
+package filesystem
+
+import (
+	"io/fs"
+	"time"
+
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// LocalFS 由本地目录提供的文件系统, 可以得到文件在磁盘上的路径,
+// 用于git统计等需要真实路径的功能
+type LocalFS interface {
+	LocalPath(name string) string
+}
+
+// RepositoryFS 由git仓库提供的文件系统
+type RepositoryFS interface {
+	Repository() *gitv.Repository
+	Commit() (*object.Commit, error)
+	// Revision 返回文件系统对应的引用(分支/标签/提交)
+	Revision() string
+	// RepositoryPath 返回文件在仓库中的路径, 用于按目录或文件统计提交
+	RepositoryPath(name string) string
+}
+
+// selfName 打开这个名称时返回 selfFile. 以/开头, 不是合法的 fs.FS 路径
+const selfName = "/.caddy-fs-self"
+
+// open 本包文件系统的 Open: name为selfName时返回fsys本身, 其它名称从inner中打开
+func open(fsys, inner fs.FS, name string) (fs.File, error) {
+	if name == selfName {
+		return selfFile{fsys: fsys}, nil
+	}
+	return inner.Open(name)
+}
+
+// Unwrap 返回注册的本包的文件系统. caddy 注册文件系统时会包装一层只有 Open 方法的结构,
+// 这样会丢失 fs.StatFS/fs.ReadDirFS 以及 LocalFS 等接口. 其它文件系统原样返回.
+// Unwrap 需要打开一次 selfName, 调用方应该缓存结果, 不要每个请求调用一次
+func Unwrap(fsys fs.FS) fs.FS {
+	// 包装只有 Open 方法, 有其它方法的不是包装
+	if _, ok := fsys.(fs.StatFS); ok {
+		return fsys
+	}
+	f, err := fsys.Open(selfName)
+	if err != nil {
+		return fsys
+	}
+	defer f.Close()
+	// 同名的真实文件不是 selfFile
+	if self, ok := f.(selfFile); ok {
+		return self.fsys
+	}
+	return fsys
+}
+
+// selfFile Unwrap 使用的文件, fsys 为文件系统本身
+type selfFile struct {
+	fsys fs.FS
+}
+
+func (f selfFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f selfFile) Read([]byte) (int, error)   { return 0, fs.ErrInvalid }
+func (f selfFile) Close() error               { return nil }
+func (f selfFile) Name() string               { return selfName[1:] }
+func (f selfFile) Size() int64                { return 0 }
+func (f selfFile) Mode() fs.FileMode          { return fs.ModeIrregular }
+func (f selfFile) ModTime() time.Time         { return time.Time{} }
+func (f selfFile) IsDir() bool                { return false }
+func (f selfFile) Sys() any                   { return f.fsys }
@@ -0,0 +1,55 @@
+package filesystem
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// wrapper 和 caddy 注册文件系统时的包装一样只有 Open 方法
+type wrapper struct {
+	fs.FS
+}
+
+// rootFS 和 caddy 默认的文件系统一样, 以/开头的名称从根目录打开
+type rootFS struct {
+	fstest.MapFS
+}
+
+func (r rootFS) Open(name string) (fs.File, error) {
+	return r.MapFS.Open(strings.TrimPrefix(name, "/"))
+}
+
+func TestUnwrap(t *testing.T) {
+	dir := &DirFS{FS: os.DirFS(t.TempDir())}
+	other := os.DirFS(t.TempDir())
+
+	tests := []struct {
+		name string
+		fsys fs.FS
+		want fs.FS
+	}{
+		{"wrapped module", &wrapper{FS: dir}, dir},
+		{"module", dir, dir},
+		{"wrapped other", &wrapper{FS: other}, nil},
+		{"other", other, nil},
+		// 存在和 selfName 同名的文件
+		{"wrapped file with the same name", &wrapper{FS: rootFS{fstest.MapFS{selfName[1:]: {Sys: dir}}}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want == nil {
+				want = tt.fsys
+			}
+			if got := Unwrap(tt.fsys); got != want {
+				t.Errorf("Unwrap() = %T, want %T", got, want)
+			}
+		})
+	}
+	if _, err := fs.Stat(dir, selfName[1:]); err == nil {
+		t.Errorf("%s is visible as a file", selfName[1:])
+	}
+}
@@ -0,0 +1,119 @@
+package filesystem
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/caddyconfig/caddyfile"
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/kingreatwill/caddy-modules/markdown/git"
+)
+
+func init() {
+	caddy.RegisterModule(GitFS{})
+}
+
+// GitFS 直接读取git仓库中某个分支/标签/提交的文件树, 不需要检出. Syntax:
+//
+//	filesystem <name> git <repository> {
+//	    ref <branch|tag|commit>
+//	    prefix <dir>
+//	}
+type GitFS struct {
+	// 仓库路径(工作区或者bare仓库), 支持全局占位符
+	Repo string `json:"repository,omitempty"`
+	// 分支/标签/提交, 默认 HEAD
+	Ref string `json:"ref,omitempty"`
+	// 文件树中作为根目录的子目录
+	Prefix string `json:"prefix,omitempty"`
+
+	fs.FS  `json:"-"`
+	tree   *git.TreeFS
+	prefix string
+}
+
+func (GitFS) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "caddy.fs.git",
+		New: func() caddy.Module { return new(GitFS) },
+	}
+}
+
+// Provision sets up the module. #caddy.Provisioner
+func (g *GitFS) Provision(ctx caddy.Context) error {
+	name := caddy.NewReplacer().ReplaceAll(g.Repo, "")
+	repo, err := git.Open(name)
+	if err != nil {
+		return fmt.Errorf("opening git filesystem %s: %v", name, err)
+	}
+	g.tree = git.NewTreeFS(repo, g.Ref)
+	if _, err := g.tree.Commit(); err != nil {
+		return fmt.Errorf("git filesystem %s: resolving %s: %v", name, g.tree.Revision(), err)
+	}
+	g.FS = g.tree
+	g.prefix = path.Clean("/" + g.Prefix)[1:]
+	if g.prefix != "" {
+		g.FS, err = fs.Sub(g.tree, g.prefix)
+		if err != nil {
+			return fmt.Errorf("git filesystem prefix %s: %v", g.Prefix, err)
+		}
+	}
+	return nil
+}
+
+// Repository 返回文件系统所在的仓库
+func (g *GitFS) Repository() *gitv.Repository { return g.tree.Repository() }
+
+// Commit 返回引用当前指向的提交
+func (g *GitFS) Commit() (*object.Commit, error) { return g.tree.Commit() }
+
+// Revision 返回文件系统对应的引用
+func (g *GitFS) Revision() string { return g.tree.Revision() }
+
+// RepositoryPath 返回文件在仓库中的路径
+func (g *GitFS) RepositoryPath(name string) string { return path.Join(g.prefix, name) }
+
+func (g *GitFS) Open(name string) (fs.File, error)          { return open(g, g.FS, name) }
+func (g *GitFS) Stat(name string) (fs.FileInfo, error)      { return fs.Stat(g.FS, name) }
+func (g *GitFS) ReadDir(name string) ([]fs.DirEntry, error) { return fs.ReadDir(g.FS, name) }
+func (g *GitFS) ReadFile(name string) ([]byte, error)       { return fs.ReadFile(g.FS, name) }
+
+// UnmarshalCaddyfile sets up the module from Caddyfile tokens.
+func (g *GitFS) UnmarshalCaddyfile(h *caddyfile.Dispenser) error {
+	h.Next() // consume module name
+	if !h.Args(&g.Repo) {
+		return h.ArgErr()
+	}
+	if h.NextArg() {
+		return h.ArgErr()
+	}
+	for h.NextBlock(0) {
+		switch h.Val() {
+		case "ref":
+			if !h.Args(&g.Ref) {
+				return h.ArgErr()
+			}
+		case "prefix":
+			if !h.Args(&g.Prefix) {
+				return h.ArgErr()
+			}
+		default:
+			return h.Errf("unrecognized subdirective '%s'", h.Val())
+		}
+	}
+	return nil
+}
+
+// Interface guards
+var (
+	_ caddy.Provisioner     = (*GitFS)(nil)
+	_ caddyfile.Unmarshaler = (*GitFS)(nil)
+	_ fs.StatFS             = (*GitFS)(nil)
+	_ fs.ReadDirFS          = (*GitFS)(nil)
+	_ fs.ReadFileFS         = (*GitFS)(nil)
+	_ RepositoryFS          = (*GitFS)(nil)
+)
@@ -0,0 +1,101 @@
+package filesystem
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/caddyconfig/caddyfile"
+)
+
+func init() {
+	caddy.RegisterModule(ZipFS{})
+}
+
+// ZipFS 以zip压缩包为内容的只读文件系统. Syntax:
+//
+//	filesystem <name> zip <path> {
+//	    prefix <dir>
+//	}
+type ZipFS struct {
+	// zip文件路径, 支持全局占位符
+	Path string `json:"path,omitempty"`
+	// 压缩包中作为根目录的子目录
+	Prefix string `json:"prefix,omitempty"`
+
+	fs.FS  `json:"-"`
+	reader *zip.ReadCloser
+}
+
+func (ZipFS) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "caddy.fs.zip",
+		New: func() caddy.Module { return new(ZipFS) },
+	}
+}
+
+// Provision sets up the module. #caddy.Provisioner
+func (z *ZipFS) Provision(ctx caddy.Context) error {
+	name := caddy.NewReplacer().ReplaceAll(z.Path, "")
+	reader, err := zip.OpenReader(name)
+	if err != nil {
+		return fmt.Errorf("opening zip filesystem %s: %v", name, err)
+	}
+	z.reader = reader
+	z.FS = reader
+	if prefix := path.Clean("/" + z.Prefix)[1:]; prefix != "" {
+		z.FS, err = fs.Sub(reader, prefix)
+		if err != nil {
+			reader.Close()
+			return fmt.Errorf("zip filesystem prefix %s: %v", z.Prefix, err)
+		}
+	}
+	return nil
+}
+
+// Cleanup closes the archive. #caddy.CleanerUpper
+func (z *ZipFS) Cleanup() error {
+	if z.reader != nil {
+		return z.reader.Close()
+	}
+	return nil
+}
+
+func (z *ZipFS) Open(name string) (fs.File, error)          { return open(z, z.FS, name) }
+func (z *ZipFS) Stat(name string) (fs.FileInfo, error)      { return fs.Stat(z.FS, name) }
+func (z *ZipFS) ReadDir(name string) ([]fs.DirEntry, error) { return fs.ReadDir(z.FS, name) }
+func (z *ZipFS) ReadFile(name string) ([]byte, error)       { return fs.ReadFile(z.FS, name) }
+
+// UnmarshalCaddyfile sets up the module from Caddyfile tokens.
+func (z *ZipFS) UnmarshalCaddyfile(h *caddyfile.Dispenser) error {
+	h.Next() // consume module name
+	if !h.Args(&z.Path) {
+		return h.ArgErr()
+	}
+	if h.NextArg() {
+		return h.ArgErr()
+	}
+	for h.NextBlock(0) {
+		switch h.Val() {
+		case "prefix":
+			if !h.Args(&z.Prefix) {
+				return h.ArgErr()
+			}
+		default:
+			return h.Errf("unrecognized subdirective '%s'", h.Val())
+		}
+	}
+	return nil
+}
+
+// Interface guards
+var (
+	_ caddy.Provisioner     = (*ZipFS)(nil)
+	_ caddy.CleanerUpper    = (*ZipFS)(nil)
+	_ caddyfile.Unmarshaler = (*ZipFS)(nil)
+	_ fs.StatFS             = (*ZipFS)(nil)
+	_ fs.ReadDirFS          = (*ZipFS)(nil)
+	_ fs.ReadFileFS         = (*ZipFS)(nil)
+)
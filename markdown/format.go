@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+// 支持的响应格式
+const (
+	formatHTML     = "html"
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+)
+
+// formatMediaTypes 响应格式对应的媒体类型, 按Accept协商时使用
+var formatMediaTypes = map[string]string{
+	"text/html":             formatHTML,
+	"application/xhtml+xml": formatHTML,
+	"application/json":      formatJSON,
+	"text/markdown":         formatMarkdown,
+	"text/x-markdown":       formatMarkdown,
+}
+
+// requestFormat 返回请求需要的响应格式, ?format= 优先于 Accept
+func requestFormat(r *http.Request) string {
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case "json":
+		return formatJSON
+	case "md", "markdown", "raw":
+		return formatMarkdown
+	case "html":
+		return formatHTML
+	}
+	format, bestQ := formatHTML, 0.0
+	for _, accept := range acceptValues(r.Header.Get("Accept")) {
+		f, ok := formatMediaTypes[strings.ToLower(accept.value)]
+		// 同样的权重时以先出现的为准
+		if ok && accept.q > bestQ {
+			format, bestQ = f, accept.q
+		}
+	}
+	return format
+}
+
+// acceptValue Accept类请求头中的一项及其权重
+type acceptValue struct {
+	value string
+	q     float64
+}
+
+// acceptValues 解析 Accept/Accept-Language 等请求头, 保持原来的顺序
+func acceptValues(header string) []acceptValue {
+	var values []acceptValue
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		value := strings.TrimSpace(params[0])
+		if value == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		values = append(values, acceptValue{value: value, q: q})
+	}
+	return values
+}
+
+// jsonTemplateData 序列化为json时, GitStatsData 直接输出为数组
+type jsonTemplateData struct {
+	*convert.TemplateData
+	Content      string          `json:"content"`
+	GitStatsData json.RawMessage `json:"gitStatsData,omitempty"`
+}
+
+// encodeTemplateData 把模板数据序列化为json写入w
+func encodeTemplateData(w io.Writer, data *convert.TemplateData) error {
+	jsonData := jsonTemplateData{
+		TemplateData: data,
+		Content:      string(data.Content),
+	}
+	if json.Valid([]byte(data.GitStatsData)) {
+		jsonData.GitStatsData = json.RawMessage(data.GitStatsData)
+	}
+	return json.NewEncoder(w).Encode(jsonData)
+}
+
+// etag 根据响应内容和包含的文件生成ETag, 内容相同则ETag相同
+func etag(body []byte, deps ...convert.IncludeFile) string {
+	h := fnv.New64a()
+	h.Write(body)
+	// 包含的文件的修改时间也是缓存key的一部分
+	for _, dep := range deps {
+		h.Write([]byte(dep.Name))
+		h.Write([]byte(strconv.FormatInt(dep.ModTime.UnixNano(), 36)))
+	}
+	return `"` + strconv.FormatUint(h.Sum64(), 36) + strconv.FormatInt(int64(len(body)), 36) + `"`
+}
+
+// etagMatch 判断 If-None-Match 是否包含指定的ETag
+func etagMatch(r *http.Request, tag string) bool {
+	inm := r.Header.Get("If-None-Match")
+	if inm == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(inm, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package markdown
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestJSONResponse(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":     "# Home\n\n{{< include \"part.md\" >}}\n",
+		"part.md":       "included\n",
+		"docs/guide.md": "# Guide\n",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) { md.Root = root })
+
+	tests := []struct {
+		target string
+		isFile bool
+	}{
+		{"/?format=json", true},
+		{"/docs/guide.md?format=json", true},
+		{"/docs/?format=json", false},
+	}
+	for _, tt := range tests {
+		rec := serveTest(t, md, root, newTestRequest(root, tt.target, ""))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status = %d", tt.target, rec.Code)
+			continue
+		}
+		body := rec.Body.String()
+		// 响应中不能有文件系统中的路径
+		if strings.Contains(body, root) {
+			t.Errorf("%s: response contains the root %q: %s", tt.target, root, body)
+		}
+		var data struct {
+			CurrentIsFile bool `json:"currentIsFile"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &data); err != nil {
+			t.Fatalf("%s: %v", tt.target, err)
+		}
+		if data.CurrentIsFile != tt.isFile {
+			t.Errorf("%s: currentIsFile = %v, want %v", tt.target, data.CurrentIsFile, tt.isFile)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/filesystem"
+	"go.uber.org/zap"
+)
+
+// fileKey 缓存中文件的key, 不同文件系统中同名的文件互不影响
+type fileKey struct {
+	fsys fs.FS
+	name string
+}
+
+// getFS 返回当前请求使用的文件系统, 请求的是某个版本时返回该版本的文件树
+func (md *Markdown) getFS(r *http.Request) (fs.FS, error) {
+	if v := versionFromRequest(r); v != nil {
+		return v.fsys, nil
+	}
+	return md.getSiteFS(r)
+}
+
+// getSiteFS 返回配置的文件系统
+func (md *Markdown) getSiteFS(r *http.Request) (fs.FS, error) {
+	repl := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
+	fsName := repl.ReplaceAll(md.FileSystem, "")
+	fsys, ok := md.fsmap.Get(fsName)
+	if !ok {
+		return nil, caddyhttp.Error(http.StatusNotFound, fmt.Errorf("filesystem not found: %s", fsName))
+	}
+	return md.unwrapFS(fsys), nil
+}
+
+// unwrapFS 返回注册的原始文件系统, 见 filesystem.Unwrap.
+// 每个注册的文件系统只解包一次, 配置中的文件系统在 Provision 时已经解包
+func (md *Markdown) unwrapFS(fsys fs.FS) fs.FS {
+	if v, ok := md.filesystems.Load(fsys); ok {
+		return v.(fs.FS)
+	}
+	inner := filesystem.Unwrap(fsys)
+	md.filesystems.Store(fsys, inner)
+	return inner
+}
+
+// provisionFS 解包默认的文件系统和配置中不含占位符的文件系统,
+// 含占位符时在第一次请求使用该文件系统时解包
+func (md *Markdown) provisionFS(ctx caddy.Context) error {
+	// 应用的加载顺序不确定, 先加载 filesystems 应用, 保证其中的文件系统已经注册
+	if _, err := ctx.AppIfConfigured("caddy.filesystems"); err != nil && !errors.Is(err, caddy.ErrNotConfigured) {
+		return err
+	}
+	md.unwrapFS(md.fsmap.Default())
+	if strings.Contains(md.FileSystem, "{") {
+		return nil
+	}
+	fsys, ok := md.fsmap.Get(md.FileSystem)
+	if !ok {
+		return fmt.Errorf("filesystem not found: %s", md.FileSystem)
+	}
+	md.unwrapFS(fsys)
+	return nil
+}
+
+func (md *Markdown) listdir(fsys fs.FS, pathname string) (fileInfos []fs.FileInfo) {
+	dirEntries, err := fs.ReadDir(fsys, pathname)
+	if err != nil {
+		md.logger.Error("read dir error:", zap.String("dir", pathname), zap.Error(err))
+		return nil
+	}
+	for _, de := range dirEntries {
+		fi, _ := de.Info()
+		fileInfos = append(fileInfos, fi)
+	}
+	return
+}
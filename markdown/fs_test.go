@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/kingreatwill/caddy-modules/markdown/filesystem"
+)
+
+// countingFS 记录Open的次数
+type countingFS struct {
+	fs.FS
+	opens int
+}
+
+func (c *countingFS) Open(name string) (fs.File, error) {
+	c.opens++
+	return c.FS.Open(name)
+}
+
+func TestUnwrapFS(t *testing.T) {
+	md := newTestMarkdown(t, nil)
+	dir := &filesystem.DirFS{FS: os.DirFS(t.TempDir())}
+	wrapped := &countingFS{FS: dir}
+	for i := 0; i < 3; i++ {
+		if got := md.unwrapFS(wrapped); got != dir {
+			t.Fatalf("unwrapFS() = %T, want %T", got, dir)
+		}
+	}
+	if wrapped.opens != 1 {
+		t.Errorf("filesystem is probed %d times, want 1", wrapped.opens)
+	}
+}
+
+func TestProvisionFS(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"README.md": "# Home\n"})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.FileSystem = "default"
+	})
+	if _, ok := md.filesystems.Load(md.fsmap.Default()); !ok {
+		t.Error("default filesystem is not unwrapped at provision")
+	}
+	if rec := serveTest(t, md, root, newTestRequest(root, "/README.md", "")); rec.Code != http.StatusOK {
+		t.Errorf("status = %d", rec.Code)
+	}
+
+	bad := Markdown{}.CaddyModule().New().(*Markdown)
+	bad.FileSystem = "missing"
+	ctx, cancel := caddy.NewContext(caddy.Context{Context: context.Background()})
+	defer cancel()
+	if err := bad.Provision(ctx); err == nil {
+		t.Error("unknown filesystem is accepted")
+	}
+}
@@ -0,0 +1,310 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// TreeFS 以只读文件系统的方式访问git仓库中某个引用(分支/标签/提交)的文件树,
+// 不需要检出到工作区. 每次访问都会重新解析引用, 分支更新后会读取到最新的提交.
+//
+// TreeFS implements fs.StatFS, fs.ReadDirFS, fs.ReadFileFS and fs.SubFS.
+type TreeFS struct {
+	repo *gitv.Repository
+	ref  string
+	dir  string
+
+	mu     sync.Mutex
+	commit *object.Commit
+	tree   *object.Tree
+}
+
+// NewTreeFS 返回仓库中引用 ref 的文件系统, ref 为空时使用 HEAD
+func NewTreeFS(repo *gitv.Repository, ref string) *TreeFS {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return &TreeFS{repo: repo, ref: ref}
+}
+
+// Repository 返回文件系统所在的仓库
+func (t *TreeFS) Repository() *gitv.Repository { return t.repo }
+
+// Revision 返回文件系统对应的引用
+func (t *TreeFS) Revision() string { return t.ref }
+
+// Commit 返回引用当前指向的提交
+func (t *TreeFS) Commit() (*object.Commit, error) {
+	commit, _, err := t.resolve()
+	return commit, err
+}
+
+// RepositoryPath 返回文件在仓库中的路径
+func (t *TreeFS) RepositoryPath(name string) string {
+	return path.Join(t.dir, name)
+}
+
+// Sub 返回以子目录dir为根的文件系统
+func (t *TreeFS) Sub(dir string) (fs.FS, error) {
+	if !fs.ValidPath(dir) {
+		return nil, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrInvalid}
+	}
+	if dir == "." {
+		return t, nil
+	}
+	sub := NewTreeFS(t.repo, t.ref)
+	sub.dir = path.Join(t.dir, dir)
+	info, err := sub.Stat(".")
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "sub", Path: dir, Err: errors.New("not a directory")}
+	}
+	return sub, nil
+}
+
+// resolve 解析引用, 引用没有变化时复用上一次读取的提交和文件树
+func (t *TreeFS) resolve() (*object.Commit, *object.Tree, error) {
+	hash, err := t.repo.ResolveRevision(plumbing.Revision(t.ref))
+	if err != nil {
+		return nil, nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.commit != nil && t.commit.Hash == *hash {
+		return t.commit, t.tree, nil
+	}
+	commit, err := t.repo.CommitObject(*hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, nil, err
+	}
+	t.commit, t.tree = commit, tree
+	return commit, tree, nil
+}
+
+// lookup 查找文件或目录, 目录返回 *object.Tree
+func (t *TreeFS) lookup(op, name string) (*object.Commit, *object.Tree, *object.TreeEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, nil, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	commit, tree, err := t.resolve()
+	if err != nil {
+		return nil, nil, nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if t.dir != "" {
+		if name == "." {
+			name = t.dir
+		} else {
+			name = path.Join(t.dir, name)
+		}
+	}
+	if name == "." {
+		return commit, tree, nil, nil
+	}
+	entry, err := tree.FindEntry(name)
+	if err != nil {
+		return nil, nil, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	if entry.Mode == filemode.Dir {
+		sub, err := tree.Tree(name)
+		if err != nil {
+			return nil, nil, nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		return commit, sub, entry, nil
+	}
+	if entry.Mode == filemode.Submodule {
+		return nil, nil, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return commit, nil, entry, nil
+}
+
+func (t *TreeFS) Stat(name string) (fs.FileInfo, error) {
+	commit, tree, entry, err := t.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	if tree != nil {
+		return &treeFileInfo{name: path.Base(name), dir: true, modTime: commit.Committer.When}, nil
+	}
+	return t.entryInfo(commit, entry)
+}
+
+func (t *TreeFS) entryInfo(commit *object.Commit, entry *object.TreeEntry) (fs.FileInfo, error) {
+	info := &treeFileInfo{name: entry.Name, mode: entry.Mode, modTime: commit.Committer.When}
+	if entry.Mode == filemode.Dir {
+		info.dir = true
+		return info, nil
+	}
+	blob, err := t.repo.BlobObject(entry.Hash)
+	if err != nil {
+		return nil, err
+	}
+	info.size = blob.Size
+	return info, nil
+}
+
+func (t *TreeFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	commit, tree, _, err := t.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if tree == nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	entries := make([]fs.DirEntry, 0, len(tree.Entries))
+	for i := range tree.Entries {
+		if tree.Entries[i].Mode == filemode.Submodule {
+			continue
+		}
+		entries = append(entries, &treeDirEntry{fsys: t, commit: commit, entry: &tree.Entries[i]})
+	}
+	return entries, nil
+}
+
+func (t *TreeFS) ReadFile(name string) ([]byte, error) {
+	_, tree, entry, err := t.lookup("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if tree != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	blob, err := t.repo.BlobObject(entry.Hash)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func (t *TreeFS) Open(name string) (fs.File, error) {
+	commit, tree, entry, err := t.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if tree != nil {
+		entries, err := t.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		return &treeDir{
+			info:    &treeFileInfo{name: path.Base(name), dir: true, modTime: commit.Committer.When},
+			entries: entries,
+		}, nil
+	}
+	info, err := t.entryInfo(commit, entry)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	content, err := t.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	// 读取到内存中以支持 Seek(http.ServeContent 需要)
+	return &treeFile{info: info, Reader: bytes.NewReader(content)}, nil
+}
+
+type treeFileInfo struct {
+	name    string
+	dir     bool
+	mode    filemode.FileMode
+	size    int64
+	modTime time.Time
+}
+
+func (fi *treeFileInfo) Name() string       { return fi.name }
+func (fi *treeFileInfo) Size() int64        { return fi.size }
+func (fi *treeFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *treeFileInfo) IsDir() bool        { return fi.dir }
+func (fi *treeFileInfo) Sys() any           { return nil }
+func (fi *treeFileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0o555
+	}
+	if fi.mode == filemode.Symlink {
+		return fs.ModeSymlink | 0o444
+	}
+	if fi.mode == filemode.Executable {
+		return 0o555
+	}
+	return 0o444
+}
+
+type treeDirEntry struct {
+	fsys   *TreeFS
+	commit *object.Commit
+	entry  *object.TreeEntry
+}
+
+func (de *treeDirEntry) Name() string               { return de.entry.Name }
+func (de *treeDirEntry) IsDir() bool                { return de.entry.Mode == filemode.Dir }
+func (de *treeDirEntry) Type() fs.FileMode          { return de.mode().Type() }
+func (de *treeDirEntry) Info() (fs.FileInfo, error) { return de.fsys.entryInfo(de.commit, de.entry) }
+func (de *treeDirEntry) mode() fs.FileMode {
+	return (&treeFileInfo{dir: de.IsDir(), mode: de.entry.Mode}).Mode()
+}
+
+type treeFile struct {
+	info fs.FileInfo
+	*bytes.Reader
+}
+
+func (f *treeFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *treeFile) Close() error               { return nil }
+
+type treeDir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *treeDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *treeDir) Close() error               { return nil }
+func (d *treeDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errors.New("is a directory")}
+}
+
+func (d *treeDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
+
+// Interface guards
+var (
+	_ fs.StatFS      = (*TreeFS)(nil)
+	_ fs.ReadDirFS   = (*TreeFS)(nil)
+	_ fs.ReadFileFS  = (*TreeFS)(nil)
+	_ fs.SubFS       = (*TreeFS)(nil)
+	_ fs.ReadDirFile = (*treeDir)(nil)
+	_ io.Seeker      = (*treeFile)(nil)
+)
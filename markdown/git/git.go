@@ -1,41 +1,48 @@
 package git
 
 import (
-	"log"
+	"errors"
+	"sort"
 	"time"
 
 	gitv "github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
-func GitStats(path string) (stats map[string]int64) {
-	stats = map[string]int64{}
+// Open 打开path所在的仓库, 支持bare仓库和仓库中的子目录
+func Open(path string) (*gitv.Repository, error) {
 	r, err := gitv.PlainOpen(path)
-	if err != nil {
-		log.Println("open git error:" + path)
-		return
+	if errors.Is(err, gitv.ErrRepositoryNotExists) {
+		// 子目录中向上查找 .git (DetectDotGit 不支持bare仓库)
+		r, err = gitv.PlainOpenWithOptions(path, &gitv.PlainOpenOptions{DetectDotGit: true})
 	}
+	return r, err
+}
 
-	// pull
-	// wr, err := r.Worktree()
-	// err = wr.Pull(&gitv.PullOptions{})
+// StatsOptions 提交统计的选项
+type StatsOptions struct {
+	// 只统计修改了该路径(仓库中的文件或目录)的提交, 为空时统计整个仓库
+	Path string
+	// 只统计该时间之后的提交, 为空时统计所有提交
+	Since time.Time
+	// 只统计该作者(名称或邮箱)的提交
+	Author string
+	// 同时按作者统计
+	ByAuthor bool
+}
 
-	until := time.Now()
-	cIter, err := r.Log(&gitv.LogOptions{Until: &until})
-	if err != nil {
-		log.Println("git log error:" + path)
-		return
-	}
+// DayStats 一天的提交数量
+type DayStats struct {
+	Date    string           `json:"date"`
+	Value   int64            `json:"value"`
+	Authors map[string]int64 `json:"authors,omitempty"`
+}
 
-	err = cIter.ForEach(func(c *object.Commit) error {
-		//c.Committer.When
-		s := c.Committer.When.Format("2006-01-02")
-		stats[s] = stats[s] + 1
-		return nil
-	})
-	if err != nil {
-		log.Println("git log ForEach error:" + path)
-		return
+// sortDays 按日期升序排列
+func sortDays(days map[string]*DayStats) []DayStats {
+	stats := make([]DayStats, 0, len(days))
+	for _, day := range days {
+		stats = append(stats, *day)
 	}
-	return
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Date < stats[j].Date })
+	return stats
 }
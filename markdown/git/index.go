@@ -0,0 +1,262 @@
+package git
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// indexVersion 索引文件格式的版本, 格式变化后重新建立索引
+const indexVersion = 1
+
+// Index 仓库中某个引用的提交历史索引, 包含每个提交修改的文件(用于按目录/文件统计)、
+// 每天的提交数量以及每个文件最后一次修改的提交. 更新时只遍历上一次索引之后的新提交.
+//
+// Index 建立后不会再修改, Update 返回新的索引, 可以在更新的同时并发读取.
+type Index struct {
+	Version int `json:"version"`
+	// 已经索引的提交
+	Head string `json:"head"`
+	// 提交记录, 按提交时间倒序排列
+	Commits []IndexCommit `json:"commits"`
+	// 每天的提交数量
+	Days map[string]int64 `json:"days"`
+	// 每个文件最后一次修改的提交
+	Files map[string]FileTouch `json:"files"`
+}
+
+// IndexCommit 提交记录
+type IndexCommit struct {
+	Hash   string `json:"h"`
+	When   int64  `json:"t"` // 提交时间(unix秒)
+	Date   string `json:"d"` // 提交日期(提交者的时区)
+	Author string `json:"a"`
+	Email  string `json:"e"`
+	// 相对第一个父提交修改的文件, 合并提交不记录
+	Files []string `json:"f,omitempty"`
+}
+
+// FileTouch 文件最后一次修改的提交
+type FileTouch struct {
+	Hash   string `json:"h"`
+	When   int64  `json:"t"`
+	Author string `json:"a"`
+}
+
+// RepositoryDir 返回仓库的git目录, 用于区分不同的仓库; 不是本地仓库时返回空
+func RepositoryDir(r *gitv.Repository) string {
+	if s, ok := r.Storer.(*filesystem.Storage); ok {
+		return s.Filesystem().Root()
+	}
+	return ""
+}
+
+// LoadIndex 读取保存的索引, 文件不存在或者格式版本不同时返回 os.ErrNotExist
+func LoadIndex(filename string) (*Index, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	idx := new(Index)
+	if err := json.NewDecoder(zr).Decode(idx); err != nil {
+		return nil, err
+	}
+	if idx.Version != indexVersion {
+		return nil, os.ErrNotExist
+	}
+	return idx, nil
+}
+
+// Save 保存索引(gzip压缩的json), 先写入临时文件再重命名
+func (idx *Index) Save(filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	zw := gzip.NewWriter(tmp)
+	err = json.NewEncoder(zw).Encode(idx)
+	if err == nil {
+		err = zw.Close()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// Update 把从head可以到达的新提交加入索引, 返回新的索引.
+// head 不是已索引提交的后代时(例如强制推送), 重新建立索引.
+func (idx *Index) Update(r *gitv.Repository, head plumbing.Hash) (*Index, error) {
+	if idx != nil && idx.Head == head.String() {
+		return idx, nil
+	}
+	headCommit, err := r.CommitObject(head)
+	if err != nil {
+		return nil, err
+	}
+
+	next := &Index{Version: indexVersion, Days: map[string]int64{}, Files: map[string]FileTouch{}}
+	seen := map[plumbing.Hash]bool{}
+	if idx != nil && idx.Head != "" && idx.descendant(r, headCommit) {
+		next.Commits = idx.Commits
+		for date, n := range idx.Days {
+			next.Days[date] = n
+		}
+		for name, touch := range idx.Files {
+			next.Files[name] = touch
+		}
+		for _, c := range idx.Commits {
+			seen[plumbing.NewHash(c.Hash)] = true
+		}
+	}
+
+	var added []IndexCommit
+	iter := object.NewCommitPreorderIter(headCommit, seen, nil)
+	defer iter.Close()
+	err = iter.ForEach(func(c *object.Commit) error {
+		files, err := changedFiles(c)
+		if err != nil {
+			return fmt.Errorf("commit %s: %v", c.Hash, err)
+		}
+		added = append(added, IndexCommit{
+			Hash:   c.Hash.String(),
+			When:   c.Committer.When.Unix(),
+			Date:   c.Committer.When.Format("2006-01-02"),
+			Author: c.Author.Name,
+			Email:  c.Author.Email,
+			Files:  files,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range added {
+		next.Days[c.Date]++
+		for _, name := range c.Files {
+			if touch, ok := next.Files[name]; !ok || touch.When < c.When {
+				next.Files[name] = FileTouch{Hash: c.Hash, When: c.When, Author: c.Author}
+			}
+		}
+	}
+	next.Commits = append(added, next.Commits...)
+	sort.SliceStable(next.Commits, func(i, j int) bool { return next.Commits[i].When > next.Commits[j].When })
+	next.Head = head.String()
+	return next, nil
+}
+
+// descendant head是否是已索引提交的后代
+func (idx *Index) descendant(r *gitv.Repository, head *object.Commit) bool {
+	old, err := r.CommitObject(plumbing.NewHash(idx.Head))
+	if err != nil {
+		return false
+	}
+	ok, err := old.IsAncestor(head)
+	return err == nil && ok
+}
+
+// changedFiles 返回提交相对第一个父提交修改(增加/修改/删除)的文件, 合并提交返回空
+func changedFiles(c *object.Commit) ([]string, error) {
+	if c.NumParents() > 1 {
+		return nil, nil
+	}
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if c.NumParents() == 1 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+// Stats 按提交日期统计已索引的提交中每天的提交数量, 按日期升序排列
+func (idx *Index) Stats(opts StatsOptions) []DayStats {
+	scope := strings.Trim(opts.Path, "/")
+	if scope == "." {
+		scope = ""
+	}
+	days := map[string]*DayStats{}
+	for _, c := range idx.Commits {
+		if !opts.Since.IsZero() && c.When < opts.Since.Unix() {
+			break
+		}
+		if opts.Author != "" && !strings.EqualFold(opts.Author, c.Author) && !strings.EqualFold(opts.Author, c.Email) {
+			continue
+		}
+		if scope != "" && !touches(c.Files, scope) {
+			continue
+		}
+		day, ok := days[c.Date]
+		if !ok {
+			day = &DayStats{Date: c.Date}
+			days[c.Date] = day
+		}
+		day.Value++
+		if opts.ByAuthor {
+			if day.Authors == nil {
+				day.Authors = map[string]int64{}
+			}
+			day.Authors[c.Author]++
+		}
+	}
+	return sortDays(days)
+}
+
+// LastTouch 返回文件最后一次修改的提交
+func (idx *Index) LastTouch(name string) (FileTouch, bool) {
+	touch, ok := idx.Files[strings.Trim(name, "/")]
+	return touch, ok
+}
+
+// touches 是否修改了scope(文件或目录)中的文件
+func touches(files []string, scope string) bool {
+	for _, name := range files {
+		if name == scope || strings.HasPrefix(name, scope+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,247 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// localLoader 加载本地仓库, 支持bare仓库和带有工作区的仓库
+type localLoader struct{}
+
+func (localLoader) Load(ep *transport.Endpoint) (storer.Storer, error) {
+	for _, dir := range []string{ep.Path, filepath.Join(ep.Path, gitv.GitDirName)} {
+		if _, err := os.Stat(filepath.Join(dir, "config")); err == nil {
+			return filesystem.NewStorage(osfs.New(dir), cache.NewObjectLRUDefault()), nil
+		}
+	}
+	return nil, transport.ErrRepositoryNotFound
+}
+
+// PullOptions 同步仓库的选项
+type PullOptions struct {
+	// 远程仓库名, 默认 origin
+	Remote string
+	// 需要快进的分支, 默认当前分支
+	Branch string
+	Auth   transport.AuthMethod
+}
+
+// Pull 从远程仓库获取更新并快进(fast-forward)分支, 返回仓库是否有变化.
+// 带有工作区的仓库同时更新工作区, bare仓库直接更新本地分支.
+func Pull(path string, opts PullOptions) (updated bool, err error) {
+	r, err := Open(path)
+	if err != nil {
+		return false, err
+	}
+	if opts.Remote == "" {
+		opts.Remote = gitv.DefaultRemoteName
+	}
+
+	fetchOpts := &gitv.FetchOptions{RemoteName: opts.Remote, Tags: gitv.AllTags, Auth: opts.Auth}
+	wt, err := r.Worktree()
+	if errors.Is(err, gitv.ErrIsBareRepository) {
+		// bare仓库没有工作区, 直接强制更新本地分支
+		fetchOpts.RefSpecs = []config.RefSpec{"+refs/heads/*:refs/heads/*"}
+		if err := unpackRefs(r, "refs/heads/"); err != nil {
+			return false, err
+		}
+		err = fetch(r, fetchOpts)
+		if errors.Is(err, gitv.NoErrAlreadyUpToDate) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := unpackRefs(r, "refs/remotes/"+opts.Remote+"/"); err != nil {
+		return false, err
+	}
+	err = fetch(r, fetchOpts)
+	if err != nil && !errors.Is(err, gitv.NoErrAlreadyUpToDate) {
+		return false, err
+	}
+	updated = err == nil
+
+	head, err := r.Head()
+	if err != nil {
+		return updated, err
+	}
+	branch := head.Name()
+	if opts.Branch != "" {
+		branch = plumbing.NewBranchReferenceName(opts.Branch)
+	}
+	local, err := r.Reference(branch, true)
+	if err != nil {
+		return updated, err
+	}
+	remote, err := r.Reference(plumbing.NewRemoteReferenceName(opts.Remote, branch.Short()), true)
+	if err != nil {
+		return updated, err
+	}
+	if local.Hash() == remote.Hash() {
+		return updated, nil
+	}
+
+	// 只允许快进, 本地有新的提交时返回错误
+	localCommit, err := r.CommitObject(local.Hash())
+	if err != nil {
+		return updated, err
+	}
+	remoteCommit, err := r.CommitObject(remote.Hash())
+	if err != nil {
+		return updated, err
+	}
+	if ok, err := localCommit.IsAncestor(remoteCommit); err != nil || !ok {
+		if err == nil {
+			err = gitv.ErrNonFastForwardUpdate
+		}
+		return updated, err
+	}
+	if branch != head.Name() {
+		return true, r.Storer.SetReference(plumbing.NewHashReference(branch, remote.Hash()))
+	}
+	// 当前分支: 移动分支并更新工作区, 保留未提交的修改
+	err = wt.Reset(&gitv.ResetOptions{Commit: remote.Hash(), Mode: gitv.MergeReset})
+	return err == nil, err
+}
+
+// fetch 获取远程仓库的更新. 本地路径(file://)的远程仓库直接在进程内读取:
+// go-git 的 file 协议需要 git-upload-pack 命令, 不修改全局注册的协议以免影响其它使用 go-git 的模块.
+func fetch(r *gitv.Repository, opts *gitv.FetchOptions) error {
+	remote, err := r.Remote(opts.RemoteName)
+	if err != nil {
+		return err
+	}
+	cfg := remote.Config()
+	ep, err := transport.NewEndpoint(cfg.URLs[0])
+	if err != nil {
+		return err
+	}
+	if ep.Protocol != "file" {
+		return r.Fetch(opts)
+	}
+	specs := opts.RefSpecs
+	if len(specs) == 0 {
+		specs = cfg.Fetch
+	}
+	if opts.Tags == gitv.AllTags {
+		specs = append(specs, "refs/tags/*:refs/tags/*")
+	}
+	updated, err := fetchLocal(r, ep, specs)
+	if err == nil && !updated {
+		return gitv.NoErrAlreadyUpToDate
+	}
+	return err
+}
+
+// fetchLocal 按refspecs从本地仓库复制引用和缺少的对象, 不是强制更新(+)的引用只在不存在时创建
+func fetchLocal(r *gitv.Repository, ep *transport.Endpoint, specs []config.RefSpec) (bool, error) {
+	src, err := localLoader{}.Load(ep)
+	if err != nil {
+		return false, err
+	}
+	refs, err := src.IterReferences()
+	if err != nil {
+		return false, err
+	}
+	var updates []*plumbing.Reference
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		for _, spec := range specs {
+			if !spec.Match(ref.Name()) {
+				continue
+			}
+			dst := spec.Dst(ref.Name())
+			local, err := r.Storer.Reference(dst)
+			if err == nil && (local.Hash() == ref.Hash() || !spec.IsForceUpdate()) {
+				return nil
+			}
+			updates = append(updates, plumbing.NewHashReference(dst, ref.Hash()))
+			return nil
+		}
+		return nil
+	})
+	if err != nil || len(updates) == 0 {
+		return false, err
+	}
+
+	// 本地已有的引用可以到达的对象不需要复制
+	var wants, haves []plumbing.Hash
+	for _, ref := range updates {
+		wants = append(wants, ref.Hash())
+	}
+	localRefs, err := r.Storer.IterReferences()
+	if err != nil {
+		return false, err
+	}
+	_ = localRefs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference {
+			haves = append(haves, ref.Hash())
+		}
+		return nil
+	})
+	hashes, err := revlist.ObjectsWithStorageForIgnores(src, r.Storer, wants, haves)
+	if err != nil {
+		return false, err
+	}
+	for _, h := range hashes {
+		if r.Storer.HasEncodedObject(h) == nil {
+			continue
+		}
+		obj, err := src.EncodedObject(plumbing.AnyObject, h)
+		if err != nil {
+			return false, err
+		}
+		if _, err := r.Storer.SetEncodedObject(obj); err != nil {
+			return false, err
+		}
+	}
+	for _, ref := range updates {
+		if err := r.Storer.SetReference(ref); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// unpackRefs 把前缀为prefix的引用都写为松散引用(内容不变).
+// go-git 更新只存在于 packed-refs 中的引用时会返回 "reference has changed concurrently",
+// 而 git clone 创建的远程分支默认都在 packed-refs 中.
+func unpackRefs(r *gitv.Repository, prefix string) error {
+	refs, err := r.References()
+	if err != nil {
+		return err
+	}
+	var matched []*plumbing.Reference
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference && strings.HasPrefix(ref.Name().String(), prefix) {
+			matched = append(matched, ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, ref := range matched {
+		if err := r.Storer.SetReference(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
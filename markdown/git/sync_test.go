@@ -0,0 +1,102 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// commitFile 在仓库的工作区中写入文件并提交
+func commitFile(t *testing.T, r *gitv.Repository, name, content string) plumbing.Hash {
+	t.Helper()
+	wt, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wt.Filesystem.Root(), name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Add(name); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := wt.Commit("update "+name, &gitv.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestPullLocalRemote(t *testing.T) {
+	originDir := t.TempDir()
+	origin, err := gitv.PlainInit(originDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, origin, "a.md", "one")
+
+	tests := []struct {
+		name string
+		bare bool
+	}{
+		{"bare", true},
+		{"worktree", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			r, err := gitv.PlainInit(dir, tt.bare)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{originDir}}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.bare {
+				if updated, err := Pull(dir, PullOptions{}); err != nil || !updated {
+					t.Fatalf("first pull: updated = %v, err = %v", updated, err)
+				}
+			} else {
+				// 还没有提交的工作区: 获取后检出远程分支
+				_, _ = Pull(dir, PullOptions{})
+				head, err := origin.Head()
+				if err != nil {
+					t.Fatal(err)
+				}
+				first := head.Hash()
+				if err := r.Storer.SetReference(plumbing.NewHashReference(plumbing.Master, first)); err != nil {
+					t.Fatal(err)
+				}
+				wt, _ := r.Worktree()
+				if err := wt.Reset(&gitv.ResetOptions{Commit: first, Mode: gitv.HardReset}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if updated, err := Pull(dir, PullOptions{}); err != nil || updated {
+				t.Fatalf("pull without changes: updated = %v, err = %v", updated, err)
+			}
+
+			second := commitFile(t, origin, "a.md", "two "+tt.name)
+			if updated, err := Pull(dir, PullOptions{}); err != nil || !updated {
+				t.Fatalf("pull after commit: updated = %v, err = %v", updated, err)
+			}
+			ref, err := r.Reference(plumbing.Master, true)
+			if err != nil || ref.Hash() != second {
+				t.Fatalf("master = %v, want %v (err %v)", ref, second, err)
+			}
+			if !tt.bare {
+				content, _ := os.ReadFile(filepath.Join(dir, "a.md"))
+				if string(content) != "two "+tt.name {
+					t.Errorf("worktree a.md = %q", content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package markdown
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/kingreatwill/caddy-modules/markdown/git"
+	"go.uber.org/zap"
+)
+
+// repoIndex 仓库中某个引用的提交索引, 在后台增量更新并保存到文件
+type repoIndex struct {
+	file     string // 保存索引的文件, 为空时只保存在内存中
+	load     sync.Once
+	index    atomic.Pointer[git.Index]
+	updating atomic.Bool
+}
+
+// gitIndex 返回仓库中引用ref的索引, 索引落后于head时在后台更新.
+// 请求不会等待遍历提交历史, 还没有建立索引时返回nil.
+func (md *Markdown) gitIndex(repo *gitv.Repository, ref string, head plumbing.Hash) *git.Index {
+	dir := git.RepositoryDir(repo)
+	key := dir + "\x00" + ref
+	if dir == "" {
+		key = fmt.Sprintf("%p\x00%s", repo, ref)
+	}
+	v, ok := md.indexes.Load(key)
+	if !ok {
+		v, _ = md.indexes.LoadOrStore(key, &repoIndex{file: md.indexFile(dir, ref)})
+	}
+	ri := v.(*repoIndex)
+	// 重启后先使用保存的索引, 只需要遍历新的提交
+	ri.load.Do(func() {
+		if ri.file == "" {
+			return
+		}
+		idx, err := git.LoadIndex(ri.file)
+		if err == nil {
+			ri.index.Store(idx)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			md.logger.Warn("loading git index", zap.String("file", ri.file), zap.Error(err))
+		}
+	})
+
+	idx := ri.index.Load()
+	if (idx == nil || idx.Head != head.String()) && ri.updating.CompareAndSwap(false, true) {
+		go md.updateIndex(ri, repo, ref, head)
+	}
+	return idx
+}
+
+// indexFile 返回保存索引的文件
+func (md *Markdown) indexFile(dir, ref string) string {
+	if dir == "" || md.GitIndexDir == "" || md.GitIndexDir == "off" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(dir + "\x00" + ref))
+	return filepath.Join(md.GitIndexDir, hex.EncodeToString(sum[:8])+".json.gz")
+}
+
+// updateIndex 增量更新索引并保存
+func (md *Markdown) updateIndex(ri *repoIndex, repo *gitv.Repository, ref string, head plumbing.Hash) {
+	defer ri.updating.Store(false)
+	start := time.Now()
+	idx := ri.index.Load()
+	next, err := idx.Update(repo, head)
+	if err != nil {
+		md.logger.Error("updating git index", zap.String("ref", ref), zap.Error(err))
+		return
+	}
+	if next == idx {
+		return
+	}
+	ri.index.Store(next)
+	md.logger.Info("git index updated",
+		zap.String("repository", git.RepositoryDir(repo)),
+		zap.String("ref", ref),
+		zap.Int("commits", len(next.Commits)),
+		zap.Duration("duration", time.Since(start)))
+	if ri.file != "" {
+		if err := next.Save(ri.file); err != nil {
+			md.logger.Warn("saving git index", zap.String("file", ri.file), zap.Error(err))
+		}
+	}
+}
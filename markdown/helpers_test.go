@@ -0,0 +1,87 @@
+package markdown
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+)
+
+// newTestMarkdown 返回使用默认配置的handler, setup在Provision之前修改配置
+func newTestMarkdown(t *testing.T, setup func(md *Markdown)) *Markdown {
+	t.Helper()
+	md := Markdown{}.CaddyModule().New().(*Markdown)
+	if setup != nil {
+		setup(md)
+	}
+	ctx, cancel := caddy.NewContext(caddy.Context{Context: context.Background()})
+	t.Cleanup(cancel)
+	if err := md.Provision(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return md
+}
+
+// newTestRequest 返回root为站点根目录的请求, user不为空时是登录用户, groups为用户所在的组
+func newTestRequest(root, target, user string, groups ...string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	repl := caddy.NewReplacer()
+	repl.Set("http.vars.root", root)
+	if user != "" {
+		repl.Set("http.auth.user.id", user)
+		repl.Set("http.auth.user.groups", strings.Join(groups, ","))
+	}
+	ctx := context.WithValue(r.Context(), caddy.ReplacerCtxKey, repl)
+	ctx = context.WithValue(ctx, caddyhttp.OriginalRequestCtxKey, *r)
+	ctx = context.WithValue(ctx, caddyhttp.VarsCtxKey, map[string]any{})
+	return r.WithContext(ctx)
+}
+
+// writeFiles 在root下创建文件, key为相对路径
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		filename := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// fileServer 和 file_server 一样返回root下的文件, 目录返回其中的README.md
+func fileServer(root string) caddyhttp.Handler {
+	return caddyhttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		filename := filepath.Join(root, filepath.FromSlash(r.URL.Path))
+		if info, err := os.Stat(filename); err == nil && info.IsDir() {
+			filename = filepath.Join(filename, "README.md")
+		}
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return caddyhttp.Error(http.StatusNotFound, err)
+		}
+		if strings.HasSuffix(filename, ".md") {
+			w.Header().Set("Content-Type", "text/markdown")
+		}
+		_, err = w.Write(content)
+		return err
+	})
+}
+
+// serveTest 处理请求, 返回的错误转换为响应的状态码
+func serveTest(t *testing.T, md *Markdown, root string, r *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	if err := md.ServeHTTP(rec, r, fileServer(root)); err != nil {
+		rec.Code = errorStatus(err)
+	}
+	return rec
+}
@@ -0,0 +1,449 @@
+package markdown
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"go.uber.org/zap"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// 默认的图片宽度, 请求的宽度向上取到其中一个, 避免任意尺寸占满缓存
+var defaultImageWidths = []int{320, 640, 960, 1280, 1920}
+
+const (
+	defaultImageQuality = 80
+	// 缓存文件超过这个时间没有使用时删除
+	defaultImageCacheMaxAge = 30 * 24 * time.Hour
+	// 清理缓存目录的间隔
+	imageCachePruneInterval = time.Hour
+	// 解码前检查图片的像素数, 避免解压炸弹
+	maxImagePixels = 50 << 20
+)
+
+// imageTypes 可以缩放的图片和对应的格式
+var imageTypes = map[string]string{
+	".png":  "png",
+	".jpg":  "jpeg",
+	".jpeg": "jpeg",
+	".gif":  "gif",
+	".webp": "webp",
+}
+
+// Images 缩放和转换图片(?w=800&fmt=webp), 结果缓存到磁盘. 使用纯Go的编解码, webp为无损编码.
+type Images struct {
+	// 缓存目录. Default: <caddy data dir>/markdown/images
+	CacheDir string `json:"cache_dir,omitempty"`
+	// 允许的宽度, 请求的宽度向上取到其中一个, srcset也使用这些宽度. Default: 320 640 960 1280 1920
+	Widths []int `json:"widths,omitempty"`
+	// jpeg的质量(1-100). Default: 80
+	Quality int `json:"quality,omitempty"`
+	// srcset中图片的格式(webp, jpeg, png), 为空时使用原来的格式
+	Format string `json:"format,omitempty"`
+	// 渲染markdown时为本地图片加上 srcset、width/height 和 loading="lazy"
+	Srcset bool `json:"srcset,omitempty"`
+	// 缓存文件超过这个时间没有使用时删除(包括原图修改后不再使用的旧文件). Default: 720h
+	CacheMaxAge caddy.Duration `json:"cache_max_age,omitempty"`
+
+	locks sync.Map // 正在生成的缓存文件
+}
+
+// imageSize 缓存的图片尺寸
+type imageSize struct {
+	modTime       time.Time
+	size          int64
+	width, height int
+}
+
+// provisionImages 设置图片的默认值, 并在后台定时清理缓存, caddy重新加载配置时退出
+func (md *Markdown) provisionImages(ctx caddy.Context) error {
+	if md.Images == nil {
+		return nil
+	}
+	if md.Images.CacheDir == "" {
+		md.Images.CacheDir = filepath.Join(caddy.AppDataDir(), "markdown", "images")
+	}
+	if len(md.Images.Widths) == 0 {
+		md.Images.Widths = defaultImageWidths
+	}
+	sort.Ints(md.Images.Widths)
+	if md.Images.Quality == 0 {
+		md.Images.Quality = defaultImageQuality
+	}
+	if md.Images.Quality < 1 || md.Images.Quality > 100 {
+		return fmt.Errorf("images: quality must be between 1 and 100, got %d", md.Images.Quality)
+	}
+	if md.Images.Format != "" {
+		format, ok := imageFormat(md.Images.Format)
+		if !ok {
+			return fmt.Errorf("images: unsupported format '%s'", md.Images.Format)
+		}
+		md.Images.Format = format
+	}
+	if md.Images.CacheMaxAge <= 0 {
+		md.Images.CacheMaxAge = caddy.Duration(defaultImageCacheMaxAge)
+	}
+
+	go func() {
+		ticker := time.NewTicker(imageCachePruneInterval)
+		defer ticker.Stop()
+		for {
+			if n, err := md.Images.pruneCache(time.Now()); err != nil {
+				md.logger.Warn("pruning image cache", zap.String("dir", md.Images.CacheDir), zap.Error(err))
+			} else if n > 0 {
+				md.logger.Debug("pruned image cache", zap.String("dir", md.Images.CacheDir), zap.Int("removed", n))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// pruneCache 删除超过 CacheMaxAge 没有使用的缓存文件, 返回删除的文件数
+func (img *Images) pruneCache(now time.Time) (int, error) {
+	removed := 0
+	expired := now.Add(-time.Duration(img.CacheMaxAge))
+	err := filepath.WalkDir(img.CacheDir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !info.ModTime().Before(expired) {
+			return nil
+		}
+		if err := os.Remove(name); err == nil {
+			removed++
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// imageFormat 返回输出的格式
+func imageFormat(name string) (string, bool) {
+	switch strings.ToLower(name) {
+	case "webp":
+		return "webp", true
+	case "jpeg", "jpg":
+		return "jpeg", true
+	case "png":
+		return "png", true
+	}
+	return "", false
+}
+
+// isImageRequest 是否需要缩放或者转换图片
+func (md *Markdown) isImageRequest(r *http.Request) bool {
+	if md.Images == nil {
+		return false
+	}
+	query := r.URL.Query()
+	if !query.Has("w") && !query.Has("fmt") {
+		return false
+	}
+	_, ok := imageTypes[strings.ToLower(path.Ext(r.URL.Path))]
+	return ok
+}
+
+// serveImage 返回缩放和转换后的图片, 不需要处理(例如gif动图)时交给后面的handler返回原图
+func (md *Markdown) serveImage(w http.ResponseWriter, r *http.Request, next caddyhttp.Handler) error {
+	fsys, err := md.getFS(r)
+	if err != nil {
+		return err
+	}
+	filename := caddyhttp.SanitizedPathJoin(md.getRoot(r), r.URL.Path)
+	info, err := fs.Stat(fsys, filename)
+	if err != nil || info.IsDir() {
+		return caddyhttp.Error(http.StatusNotFound, err)
+	}
+
+	query := r.URL.Query()
+	srcFormat := imageTypes[strings.ToLower(path.Ext(filename))]
+	format := srcFormat
+	if f := query.Get("fmt"); f != "" {
+		var ok bool
+		if format, ok = imageFormat(f); !ok {
+			return caddyhttp.Error(http.StatusBadRequest, fmt.Errorf("unsupported image format: %s", f))
+		}
+	} else if srcFormat == "gif" {
+		// 缩放会丢失动画
+		return next.ServeHTTP(w, r)
+	}
+	width := 0
+	if s := query.Get("w"); s != "" {
+		if width, err = strconv.Atoi(s); err != nil || width <= 0 {
+			return caddyhttp.Error(http.StatusBadRequest, fmt.Errorf("bad image width: %s", s))
+		}
+		width = md.Images.snapWidth(width)
+	}
+	size, err := md.imageSize(fsys, filename, info)
+	if err != nil {
+		return caddyhttp.Error(http.StatusUnsupportedMediaType, err)
+	}
+	if width >= size.width {
+		// 不放大
+		width = 0
+	}
+	if width == 0 && format == srcFormat {
+		return next.ServeHTTP(w, r)
+	}
+
+	repl := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
+	source := repl.ReplaceAll(md.FileSystem, "")
+	if v := versionFromRequest(r); v != nil {
+		source = "version:" + v.name
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d\x00%d\x00%d\x00%s\x00%d",
+		source, filename, info.ModTime().UnixNano(), info.Size(), width, format, md.Images.Quality)))
+	key := hex.EncodeToString(sum[:])
+	cacheFile := filepath.Join(md.Images.CacheDir, key[:2], key+"."+format)
+	if err := md.Images.ensureCached(cacheFile, func(wr io.Writer) error {
+		return md.Images.resize(fsys, filename, wr, width, format, srcFormat)
+	}); err != nil {
+		md.logger.Error("resizing image", zap.String("path", filename), zap.Error(err))
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+
+	f, err := os.Open(cacheFile)
+	if err != nil {
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+	defer f.Close()
+	// webp比原图大时缓存的是原来的格式
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(f, head)
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(head[:n]))
+	w.Header().Set("Etag", `"`+key[:16]+`"`)
+	http.ServeContent(w, r, "", info.ModTime(), f)
+	return nil
+}
+
+// snapWidth 把请求的宽度向上取到允许的宽度, 超过最大宽度时使用最大宽度
+func (img *Images) snapWidth(width int) int {
+	for _, w := range img.Widths {
+		if w >= width {
+			return w
+		}
+	}
+	return img.Widths[len(img.Widths)-1]
+}
+
+// ensureCached 缓存文件不存在时生成, 同一个文件同时只生成一次.
+// 使用缓存时更新修改时间, 清理缓存时按修改时间删除没有使用的文件
+func (img *Images) ensureCached(cacheFile string, generate func(io.Writer) error) error {
+	if info, err := os.Stat(cacheFile); err == nil {
+		if now := time.Now(); now.Sub(info.ModTime()) > time.Duration(img.CacheMaxAge)/2 {
+			_ = os.Chtimes(cacheFile, now, now)
+		}
+		return nil
+	}
+	v, _ := img.locks.LoadOrStore(cacheFile, new(sync.Mutex))
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	defer func() {
+		mu.Unlock()
+		img.locks.Delete(cacheFile)
+	}()
+	if _, err := os.Stat(cacheFile); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(cacheFile), filepath.Base(cacheFile)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	err = generate(tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), cacheFile)
+}
+
+// resize 解码图片, 缩放到宽度width(为0时不缩放)并编码为format.
+// webp为无损编码, 比原图大时不缩放的使用原图, 缩放的使用原来的格式(gif和webp使用png)
+func (img *Images) resize(fsys fs.FS, filename string, wr io.Writer, width int, format, srcFormat string) error {
+	data, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return err
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	dst := src
+	if b := src.Bounds(); width > 0 && width < b.Dx() {
+		height := b.Dy() * width / b.Dx()
+		if height < 1 {
+			height = 1
+		}
+		scaled := image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), src, b, draw.Src, nil)
+		dst = scaled
+	}
+	if format != "webp" {
+		return img.encode(wr, dst, format)
+	}
+	var buf bytes.Buffer
+	if err := img.encode(&buf, dst, format); err != nil {
+		return err
+	}
+	if buf.Len() <= len(data) {
+		_, err = buf.WriteTo(wr)
+		return err
+	}
+	if dst == src {
+		_, err = wr.Write(data)
+		return err
+	}
+	if srcFormat != "jpeg" {
+		srcFormat = "png"
+	}
+	return img.encode(wr, dst, srcFormat)
+}
+
+// encode 把图片编码为format
+func (img *Images) encode(wr io.Writer, m image.Image, format string) error {
+	switch format {
+	case "jpeg":
+		return jpeg.Encode(wr, m, &jpeg.Options{Quality: img.Quality})
+	case "png":
+		return png.Encode(wr, m)
+	case "webp":
+		return nativewebp.Encode(wr, m, nil)
+	}
+	return fmt.Errorf("unsupported image format: %s", format)
+}
+
+// imageSize 返回图片的尺寸, 按修改时间和大小缓存
+func (md *Markdown) imageSize(fsys fs.FS, filename string, info fs.FileInfo) (imageSize, error) {
+	key := fileKey{fsys: fsys, name: filename}
+	if v, ok := md.imageSizes.Load(key); ok {
+		size := v.(imageSize)
+		if size.modTime.Equal(info.ModTime()) && size.size == info.Size() {
+			return size, nil
+		}
+	}
+	f, err := fsys.Open(filename)
+	if err != nil {
+		return imageSize{}, err
+	}
+	defer f.Close()
+	config, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return imageSize{}, err
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return imageSize{}, errors.New("image is too large")
+	}
+	size := imageSize{modTime: info.ModTime(), size: info.Size(), width: config.Width, height: config.Height}
+	md.imageSizes.Store(key, size)
+	return size, nil
+}
+
+// imageResolver 返回渲染markdown时查找本地图片尺寸和srcset的函数, filename为当前文档
+func (md *Markdown) imageResolver(r *http.Request, filename string) convert.ImageResolver {
+	fsys, err := md.getFS(r)
+	if err != nil {
+		return nil
+	}
+	root := md.getRoot(r)
+	return func(dest string) (convert.ImageInfo, bool) {
+		if dest == "" || strings.HasPrefix(dest, "//") || strings.ContainsAny(dest, ":?#") {
+			return convert.ImageInfo{}, false
+		}
+		name, err := url.PathUnescape(dest)
+		if err != nil {
+			return convert.ImageInfo{}, false
+		}
+		ext := strings.ToLower(path.Ext(name))
+		if _, ok := imageTypes[ext]; !ok {
+			return convert.ImageInfo{}, false
+		}
+		if strings.HasPrefix(name, "/") {
+			name = caddyhttp.SanitizedPathJoin(root, name)
+		} else if name = path.Join(path.Dir(filename), name); !inRoot(root, name) {
+			// 相对路径不能超出root
+			return convert.ImageInfo{}, false
+		}
+		info, err := fs.Stat(fsys, name)
+		if err != nil || info.IsDir() {
+			return convert.ImageInfo{}, false
+		}
+		size, err := md.imageSize(fsys, name, info)
+		if err != nil {
+			return convert.ImageInfo{}, false
+		}
+		image := convert.ImageInfo{Width: size.width, Height: size.height}
+		if ext == ".gif" {
+			return image, true
+		}
+		suffix := ""
+		if md.Images.Format != "" {
+			suffix = "&fmt=" + md.Images.Format
+		}
+		for _, w := range md.Images.Widths {
+			if w < size.width {
+				image.Srcset = append(image.Srcset, convert.ImageSource{URL: dest + "?w=" + strconv.Itoa(w) + suffix, Width: w})
+			}
+		}
+		largest := dest
+		if suffix != "" {
+			largest = dest + "?" + suffix[1:]
+		}
+		image.Srcset = append(image.Srcset, convert.ImageSource{URL: largest, Width: size.width})
+		return image, true
+	}
+}
+
+// inRoot 文件系统中的文件name是否在root下
+func inRoot(root, name string) bool {
+	root = path.Clean(root)
+	if root == "." {
+		return name != ".." && !strings.HasPrefix(name, "../")
+	}
+	return name == root || strings.HasPrefix(name, strings.TrimSuffix(root, "/")+"/")
+}
@@ -0,0 +1,169 @@
+package markdown
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/caddyserver/caddy/v2"
+)
+
+func TestInRoot(t *testing.T) {
+	tests := []struct {
+		root, name string
+		want       bool
+	}{
+		{".", "a.png", true},
+		{".", "docs/a.png", true},
+		{".", "../a.png", false},
+		{".", "..", false},
+		{"/srv/site", "/srv/site/a.png", true},
+		{"/srv/site", "/srv/site", true},
+		{"/srv/site", "/srv/a.png", false},
+		{"/srv/site", "/srv/site-other/a.png", false},
+		{"/srv/site/", "/srv/site/a.png", true},
+		{"/", "/a.png", true},
+	}
+	for _, tt := range tests {
+		if got := inRoot(tt.root, tt.name); got != tt.want {
+			t.Errorf("inRoot(%q, %q) = %v, want %v", tt.root, tt.name, got, tt.want)
+		}
+	}
+}
+
+// noiseJPEG 随机像素的jpeg, 无损的webp会比它大
+func noiseJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	m := image.NewRGBA(image.Rect(0, 0, w, h))
+	rnd := rand.New(rand.NewSource(1))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			m.Set(x, y, color.RGBA{uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, m, &jpeg.Options{Quality: 50}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestServeImage(t *testing.T) {
+	root := t.TempDir()
+	photo := noiseJPEG(t, 400, 200)
+	if err := os.WriteFile(filepath.Join(root, "photo.jpg"), photo, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, root, map[string]string{"README.md": "# Home\n"})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.Images = &Images{CacheDir: t.TempDir(), Widths: []int{100}}
+	})
+
+	tests := []struct {
+		target      string
+		contentType string
+		sameAsSrc   bool
+	}{
+		// 无损的webp比原图大
+		{"/photo.jpg?fmt=webp", "image/jpeg", true},
+		// 缩放后比原图小
+		{"/photo.jpg?w=100&fmt=webp", "image/webp", false},
+		{"/photo.jpg?w=100", "image/jpeg", false},
+		{"/photo.jpg?w=100&fmt=png", "image/png", false},
+	}
+	for _, tt := range tests {
+		rec := serveTest(t, md, root, newTestRequest(root, tt.target, ""))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status = %d", tt.target, rec.Code)
+			continue
+		}
+		if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+			t.Errorf("%s: Content-Type = %q, want %q", tt.target, got, tt.contentType)
+		}
+		if got := bytes.Equal(rec.Body.Bytes(), photo); got != tt.sameAsSrc {
+			t.Errorf("%s: same as the source = %v, want %v", tt.target, got, tt.sameAsSrc)
+		}
+	}
+}
+
+func TestPruneImageCache(t *testing.T) {
+	dir := t.TempDir()
+	img := &Images{CacheDir: dir, CacheMaxAge: caddy.Duration(time.Hour)}
+	now := time.Now()
+	files := map[string]time.Duration{
+		"ab/old.webp":   2 * time.Hour,
+		"ab/fresh.webp": time.Minute,
+		"cd/old.png":    3 * time.Hour,
+	}
+	for name, age := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(filename, now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := img.pruneCache(now)
+	if err != nil || removed != 2 {
+		t.Fatalf("pruneCache() = %d, %v, want 2", removed, err)
+	}
+	for name, age := range files {
+		_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+		if exists := err == nil; exists != (age < time.Hour) {
+			t.Errorf("%s: exists = %v", name, exists)
+		}
+	}
+	// 缓存目录不存在时不是错误
+	img.CacheDir = filepath.Join(dir, "missing")
+	if _, err := img.pruneCache(now); err != nil {
+		t.Errorf("pruneCache() on a missing dir: %v", err)
+	}
+}
+
+func TestImageResolverStaysInRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "site")
+	photo := noiseJPEG(t, 40, 20)
+	for _, name := range []string{"outside.jpg", "site/docs/photo.jpg"} {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, photo, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.Images = &Images{CacheDir: t.TempDir()}
+	})
+	resolve := md.imageResolver(newTestRequest(root, "/docs/", ""), filepath.ToSlash(filepath.Join(root, "docs", "README.md")))
+
+	tests := []struct {
+		dest string
+		want bool
+	}{
+		{"photo.jpg", true},
+		{"/docs/photo.jpg", true},
+		{"../../outside.jpg", false},
+		{"/../outside.jpg", false},
+	}
+	for _, tt := range tests {
+		if _, got := resolve(tt.dest); got != tt.want {
+			t.Errorf("resolve(%q) = %v, want %v", tt.dest, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package markdown
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"go.uber.org/zap"
+)
+
+// expandIncludes 展开文档filename中包含的其它文件, 当前用户没有权限的文件不会被包含
+func (md *Markdown) expandIncludes(r *http.Request, filename, src string) (string, []convert.IncludeFile) {
+	fsys, err := md.getFS(r)
+	if err != nil {
+		md.logger.Debug("include", zap.String("path", filename), zap.Error(err))
+		return src, nil
+	}
+	root := md.getRoot(r)
+	in := &convert.Includer{
+		FS:   fsys,
+		Root: root,
+		Allow: func(name string, metaData map[string]interface{}, err error) bool {
+			rel := name
+			if root != "." {
+				rel = strings.TrimPrefix(name, root)
+			}
+			urlPath := "/" + strings.TrimPrefix(rel, "/")
+			return md.canView(r, urlPath, metaAccess(metaData, err))
+		},
+	}
+	return in.Expand(filename, src)
+}
@@ -0,0 +1,247 @@
+package markdown
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+// langCookie 保存选择的语言的cookie
+const langCookie = "lang"
+
+// languageCtxKey 请求选择的语言
+const languageCtxKey ctxKey = "markdown_language"
+
+func languageFromRequest(r *http.Request) string {
+	lang, _ := r.Context().Value(languageCtxKey).(string)
+	return lang
+}
+
+// splitLanguage 拆分其它语言的版本 name.<lang>.md, 返回 name.md 和 lang;
+// 默认语言的文件(name.md)返回的 lang 为空
+func (md *Markdown) splitLanguage(name string) (base, lang string) {
+	if len(md.Languages) < 2 || !isMarkdownFile(name) {
+		return name, ""
+	}
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	suffix := path.Ext(stem)
+	if suffix == "" {
+		return name, ""
+	}
+	for _, l := range md.Languages[1:] {
+		if strings.EqualFold(l, suffix[1:]) {
+			return strings.TrimSuffix(stem, suffix) + ext, l
+		}
+	}
+	return name, ""
+}
+
+// variantName 返回文档base(name.md)在语言lang中的文件名
+func (md *Markdown) variantName(base, lang string) string {
+	if len(md.Languages) == 0 || lang == "" || lang == md.Languages[0] {
+		return base
+	}
+	ext := path.Ext(base)
+	return strings.TrimSuffix(base, ext) + "." + lang + ext
+}
+
+// matchLanguage 返回配置中匹配tag的语言, 支持前缀匹配(en-US 匹配 en)
+func (md *Markdown) matchLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return ""
+	}
+	for _, l := range md.Languages {
+		if strings.ToLower(l) == tag {
+			return l
+		}
+	}
+	for _, l := range md.Languages {
+		l2 := strings.ToLower(l)
+		if strings.HasPrefix(tag, l2+"-") || strings.HasPrefix(l2, tag+"-") {
+			return l
+		}
+	}
+	return ""
+}
+
+// withLanguage 选择请求的语言: ?lang= 参数(同时保存到cookie), cookie, Accept-Language, 默认语言.
+// 直接请求其它语言的版本(name.<lang>.md)时使用该版本的语言.
+func (md *Markdown) withLanguage(w http.ResponseWriter, r *http.Request) *http.Request {
+	if len(md.Languages) < 2 {
+		return r
+	}
+	w.Header().Add("Vary", "Accept-Language")
+	w.Header().Add("Vary", "Cookie")
+
+	lang := ""
+	if q := r.URL.Query().Get("lang"); q != "" {
+		if lang = md.matchLanguage(q); lang != "" {
+			http.SetCookie(w, &http.Cookie{
+				Name:     langCookie,
+				Value:    lang,
+				Path:     "/",
+				MaxAge:   365 * 24 * 3600,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+	}
+	if lang == "" {
+		if c, err := r.Cookie(langCookie); err == nil {
+			lang = md.matchLanguage(c.Value)
+		}
+	}
+	if lang == "" {
+		bestQ := 0.0
+		for _, accept := range acceptValues(r.Header.Get("Accept-Language")) {
+			// 同样的权重时以先出现的为准
+			if l := md.matchLanguage(accept.value); l != "" && accept.q > bestQ {
+				lang, bestQ = l, accept.q
+			}
+		}
+	}
+	if _, l := md.splitLanguage(path.Base(r.URL.Path)); l != "" {
+		lang = l
+	}
+	if lang == "" {
+		lang = md.Languages[0]
+	}
+	return r.WithContext(context.WithValue(r.Context(), languageCtxKey, lang))
+}
+
+// languageFile 返回文档filename在语言lang中的版本, 没有该版本或者直接请求其它语言的版本时返回filename
+func (md *Markdown) languageFile(fsys fs.FS, filename, lang string) string {
+	dir, name := path.Split(filename)
+	base, current := md.splitLanguage(name)
+	if current != "" || !isMarkdownFile(name) {
+		return filename
+	}
+	variant := dir + md.variantName(base, lang)
+	if variant == filename {
+		return filename
+	}
+	if fi, err := fs.Stat(fsys, variant); err == nil && !fi.IsDir() {
+		return variant
+	}
+	return filename
+}
+
+// languageVariant 请求的文档(或者目录的索引文件)需要使用其它语言的版本时返回该版本的文件
+func (md *Markdown) languageVariant(r *http.Request) (fs.FS, string, bool) {
+	lang := languageFromRequest(r)
+	if lang == "" {
+		return nil, "", false
+	}
+	fsys, err := md.getFS(r)
+	if err != nil {
+		return nil, "", false
+	}
+	filename := strings.TrimSuffix(caddyhttp.SanitizedPathJoin(md.getRoot(r), r.URL.Path), "/")
+	info, err := fs.Stat(fsys, filename)
+	if err != nil {
+		return nil, "", false
+	}
+	if info.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			return nil, "", false
+		}
+		index, ok := md.findIndex(fsys, filename)
+		if !ok {
+			return nil, "", false
+		}
+		filename = index
+	}
+	variant := md.languageFile(fsys, filename, lang)
+	return fsys, variant, variant != filename
+}
+
+// setLanguages 填充当前文档的语言和语言切换列表
+func (md *Markdown) setLanguages(r *http.Request, fsys fs.FS, data *convert.TemplateData) {
+	lang := languageFromRequest(r)
+	if lang == "" {
+		return
+	}
+	data.Lang = lang
+	if !data.CurrentIsFile {
+		return
+	}
+	dir, name := path.Split(data.CurrentFile)
+	base, current := md.splitLanguage(name)
+	if current != "" {
+		data.Lang = current
+	} else if isMarkdownFile(name) && dir+md.variantName(base, lang) != data.CurrentFile {
+		// 没有该语言的版本, 显示的是默认语言
+		data.Lang = md.Languages[0]
+	}
+
+	// 目录使用目录的链接, 文件使用默认语言的文件, 不存在时使用各自的文件
+	urlDir := r.URL.Path
+	if !strings.HasSuffix(urlDir, "/") {
+		urlDir = path.Dir(urlDir) + "/"
+	}
+	_, baseErr := fs.Stat(fsys, dir+base)
+	var items []convert.LanguageItem
+	for _, l := range md.Languages {
+		variant := md.variantName(base, l)
+		if fi, err := fs.Stat(fsys, dir+variant); err != nil || fi.IsDir() {
+			continue
+		}
+		href := urlDir + url.PathEscape(variant)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/"):
+			href = r.URL.Path + "?lang=" + url.QueryEscape(l)
+		case baseErr == nil:
+			href = urlDir + url.PathEscape(base) + "?lang=" + url.QueryEscape(l)
+		}
+		items = append(items, convert.LanguageItem{Lang: l, Href: href, Current: l == data.Lang})
+	}
+	if len(items) > 1 {
+		data.Languages = items
+	}
+}
+
+// groupVariants 目录列表中每个文档只显示一项: 按文档(默认语言的文件名)分组其它语言的版本,
+// 返回每个文档选择显示的文件(优先使用语言lang, 然后是默认语言)以及文档的所有语言
+func (md *Markdown) groupVariants(infos []fs.FileInfo, lang string) (shown map[string]string, langs map[string][]string) {
+	shown, langs = map[string]string{}, map[string][]string{}
+	if len(md.Languages) < 2 {
+		return
+	}
+	names := map[string]bool{}
+	for _, fi := range infos {
+		if fi != nil && !fi.IsDir() {
+			names[fi.Name()] = true
+		}
+	}
+	for _, fi := range infos {
+		if fi == nil || fi.IsDir() || !isMarkdownFile(fi.Name()) {
+			continue
+		}
+		base, _ := md.splitLanguage(fi.Name())
+		if _, ok := langs[base]; ok {
+			continue
+		}
+		for _, l := range md.Languages {
+			if names[md.variantName(base, l)] {
+				langs[base] = append(langs[base], l)
+			}
+		}
+		for _, l := range []string{lang, md.Languages[0]} {
+			if name := md.variantName(base, l); names[name] {
+				shown[base] = name
+				break
+			}
+		}
+		if _, ok := shown[base]; !ok {
+			shown[base] = md.variantName(base, langs[base][0])
+		}
+	}
+	return
+}
@@ -0,0 +1,202 @@
+package markdown
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+func TestWithLanguage(t *testing.T) {
+	md := &Markdown{Languages: []string{"en", "zh", "ja"}}
+	tests := []struct {
+		name      string
+		target    string
+		cookie    string
+		accept    string
+		want      string
+		setCookie bool
+	}{
+		{"default", "/a.md", "", "", "en", false},
+		{"query", "/a.md?lang=zh", "", "", "zh", true},
+		{"query over cookie", "/a.md?lang=ja", "zh", "zh", "ja", true},
+		{"unknown query", "/a.md?lang=fr", "zh", "", "zh", false},
+		{"cookie over Accept-Language", "/a.md", "ja", "zh", "ja", false},
+		{"unknown cookie", "/a.md", "fr", "ja", "ja", false},
+		{"Accept-Language", "/a.md", "", "fr, ja;q=0.8, zh;q=0.5", "ja", false},
+		{"q-values", "/a.md", "", "zh;q=0.3, ja;q=0.9", "ja", false},
+		{"same q", "/a.md", "", "zh, ja", "zh", false},
+		{"region", "/a.md", "", "zh-CN", "zh", false},
+		{"region q-values", "/a.md", "", "en-US;q=0.5, zh-TW;q=0.8", "zh", false},
+		{"q=0", "/a.md", "", "zh;q=0", "en", false},
+		{"no match", "/a.md", "", "fr", "en", false},
+		{"variant file", "/a.ja.md", "zh", "", "ja", false},
+		{"variant file over query", "/a.ja.md?lang=zh", "", "", "ja", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: langCookie, Value: tt.cookie})
+			}
+			if tt.accept != "" {
+				r.Header.Set("Accept-Language", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			if got := languageFromRequest(md.withLanguage(rec, r)); got != tt.want {
+				t.Errorf("language = %q, want %q", got, tt.want)
+			}
+			if got := rec.Header().Get("Set-Cookie") != ""; got != tt.setCookie {
+				t.Errorf("Set-Cookie = %v, want %v", got, tt.setCookie)
+			}
+		})
+	}
+}
+
+func TestLanguageFile(t *testing.T) {
+	md := &Markdown{Languages: []string{"en", "zh"}}
+	fsys := fstest.MapFS{
+		"docs/a.md":    {},
+		"docs/a.zh.md": {},
+		"docs/b.md":    {},
+		"docs/c.zh.md": {Mode: fs.ModeDir},
+		"docs/c.md":    {},
+		"docs/d.txt":   {},
+	}
+	tests := []struct {
+		filename, lang, want string
+	}{
+		{"docs/a.md", "zh", "docs/a.zh.md"},
+		{"docs/a.md", "en", "docs/a.md"},
+		{"docs/a.md", "", "docs/a.md"},
+		// 没有该语言的版本
+		{"docs/b.md", "zh", "docs/b.md"},
+		// 直接请求其它语言的版本
+		{"docs/a.zh.md", "en", "docs/a.zh.md"},
+		{"docs/c.md", "zh", "docs/c.md"},
+		{"docs/d.txt", "zh", "docs/d.txt"},
+	}
+	for _, tt := range tests {
+		if got := md.languageFile(fsys, tt.filename, tt.lang); got != tt.want {
+			t.Errorf("languageFile(%q, %q) = %q, want %q", tt.filename, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestGroupVariants(t *testing.T) {
+	md := &Markdown{Languages: []string{"en", "zh", "ja"}}
+	fsys := fstest.MapFS{
+		"a.md":      {},
+		"a.zh.md":   {},
+		"a.ja.md":   {},
+		"b.md":      {},
+		"c.ja.md":   {},
+		"c.zh.md":   {},
+		"d.fr.md":   {},
+		"notes.txt": {},
+		"guide":     {Mode: fs.ModeDir},
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var infos []fs.FileInfo
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			t.Fatal(err)
+		}
+		infos = append(infos, info)
+	}
+
+	tests := []struct {
+		lang  string
+		shown map[string]string
+	}{
+		{"zh", map[string]string{"a.md": "a.zh.md", "b.md": "b.md", "c.md": "c.zh.md", "d.fr.md": "d.fr.md"}},
+		{"en", map[string]string{"a.md": "a.md", "b.md": "b.md", "c.md": "c.zh.md", "d.fr.md": "d.fr.md"}},
+		{"ja", map[string]string{"a.md": "a.ja.md", "b.md": "b.md", "c.md": "c.ja.md", "d.fr.md": "d.fr.md"}},
+	}
+	wantLangs := map[string][]string{"a.md": {"en", "zh", "ja"}, "b.md": {"en"}, "c.md": {"zh", "ja"}, "d.fr.md": {"en"}}
+	for _, tt := range tests {
+		shown, langs := md.groupVariants(infos, tt.lang)
+		if !reflect.DeepEqual(shown, tt.shown) {
+			t.Errorf("%s: shown = %v, want %v", tt.lang, shown, tt.shown)
+		}
+		if !reflect.DeepEqual(langs, wantLangs) {
+			t.Errorf("%s: langs = %v, want %v", tt.lang, langs, wantLangs)
+		}
+	}
+}
+
+func TestServeLanguages(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":       "# Home\n",
+		"docs/README.md":  "# Docs\n",
+		"docs/a.md":       "# A\n",
+		"docs/a.zh.md":    "# 甲\n",
+		"docs/only.zh.md": "# 只有中文\n",
+		"docs/b.md":       "# B\n",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.Languages = []string{"en", "zh"}
+	})
+
+	tests := []struct {
+		name      string
+		target    string
+		accept    string
+		lang      string
+		heading   string
+		languages []convert.LanguageItem
+	}{
+		{"default", "/docs/a.md", "", "en", "A", []convert.LanguageItem{
+			{Lang: "en", Href: "/docs/a.md?lang=en", Current: true},
+			{Lang: "zh", Href: "/docs/a.md?lang=zh"},
+		}},
+		{"Accept-Language", "/docs/a.md", "zh-CN,zh;q=0.9", "zh", "甲", []convert.LanguageItem{
+			{Lang: "en", Href: "/docs/a.md?lang=en"},
+			{Lang: "zh", Href: "/docs/a.md?lang=zh", Current: true},
+		}},
+		// 没有其它语言的版本时没有语言切换
+		{"single language", "/docs/b.md", "zh", "en", "B", nil},
+		{"variant only", "/docs/only.zh.md", "", "zh", "只有中文", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRequest(root, tt.target+"?format=json", "")
+			if tt.accept != "" {
+				r.Header.Set("Accept-Language", tt.accept)
+			}
+			rec := serveTest(t, md, root, r)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d", rec.Code)
+			}
+			var data struct {
+				Lang      string                 `json:"lang"`
+				HTML      string                 `json:"html"`
+				Languages []convert.LanguageItem `json:"languages"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &data); err != nil {
+				t.Fatal(err)
+			}
+			if data.Lang != tt.lang {
+				t.Errorf("lang = %q, want %q", data.Lang, tt.lang)
+			}
+			if !strings.Contains(data.HTML, tt.heading) {
+				t.Errorf("%s not found in %s", tt.heading, data.HTML)
+			}
+			if !reflect.DeepEqual(data.Languages, tt.languages) {
+				t.Errorf("languages = %+v, want %+v", data.Languages, tt.languages)
+			}
+		})
+	}
+}
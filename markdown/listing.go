@@ -0,0 +1,321 @@
+package markdown
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"github.com/kingreatwill/caddy-modules/markdown/template"
+)
+
+// 目录列表支持的排序字段
+const (
+	sortByName  = "name"
+	sortByMtime = "mtime"
+	sortBySize  = "size"
+	sortByTitle = "title"
+	// 阅读顺序: _order.yml、元数据中的weight/order, 然后目录在前按名称
+	sortByWeight = "weight"
+
+	sortOrderAsc  = "asc"
+	sortOrderDesc = "desc"
+)
+
+// 读取markdown元数据时最多读取的字节数
+const frontMatterMaxBytes = 64 * 1024
+
+// docMeta 缓存markdown文件元数据中的title、summary、access、weight和draft
+type docMeta struct {
+	modTime time.Time
+	size    int64
+	title   string
+	summary string
+	author  string
+	access  []string
+	weight  int
+	draft   bool
+}
+
+// isHiddenName 目前默认以.和_开头的文件不显示
+func isHiddenName(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func isMarkdownFile(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// listItems 列出目录下当前用户可见的文件和目录, 多语言的文档只显示一项(优先显示请求的语言的版本)
+func (md *Markdown) listItems(r *http.Request, fsys fs.FS, root, listDir string) []convert.TemplateFileItemData {
+	items := []convert.TemplateFileItemData{}
+	all, access := md.readItems(fsys, root, listDir, languageFromRequest(r))
+	for _, item := range all {
+		// 没有权限的文件和目录不显示
+		if md.canView(r, item.Href, access[item.Name]) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// readItems 列出目录下的文件和目录以及markdown文件元数据中的access(按名称), 多语言的文档只保留语言lang的版本.
+// 分页前就需要过滤没有权限的文档, 所以所有markdown文件的元数据都要读取(只读取文件开头的元数据块, 见 readFrontMatter),
+// 标题等来自同一个元数据块, 同时设置; 目录的weight和子条目数需要另外读取, 见 setDirWeights 和 setChildCounts
+func (md *Markdown) readItems(fsys fs.FS, root, listDir, lang string) ([]convert.TemplateFileItemData, map[string][]string) {
+	items := []convert.TemplateFileItemData{}
+	access := map[string][]string{}
+	infos := md.listdir(fsys, listDir)
+	shown, langs := md.groupVariants(infos, lang)
+	byName := make(map[string]fs.FileInfo, len(infos))
+	for _, fi := range infos {
+		if fi != nil {
+			byName[fi.Name()] = fi
+		}
+	}
+	for _, fi := range infos {
+		if fi == nil || isHiddenName(fi.Name()) {
+			continue
+		}
+		name := fi.Name()
+		var variants []string
+		if !fi.IsDir() {
+			if base, _ := md.splitLanguage(name); shown[base] != "" {
+				// 默认语言的文件存在时链接到该文件(按请求的语言显示), 否则链接到选择的版本
+				link := base
+				if byName[base] == nil {
+					link = shown[base]
+				}
+				if name != link {
+					continue
+				}
+				fi = byName[shown[base]]
+				if len(langs[base]) > 1 {
+					variants = langs[base]
+				}
+			}
+		}
+		filename := path.Join(listDir, fi.Name())
+		item := convert.TemplateFileItemData{
+			Name:          name,
+			IsFile:        !fi.IsDir(),
+			FileExtension: name,
+			Href:          strings.ReplaceAll(path.Join(listDir, name), "\\", "/"),
+			ModTime:       fi.ModTime(),
+			Languages:     variants,
+		}
+		if strings.HasPrefix(item.Href, root) {
+			item.Href = strings.Replace(item.Href, root, "", 1)
+		}
+		if !strings.HasPrefix(item.Href, "/") {
+			item.Href = "/" + item.Href
+		}
+		if !fi.IsDir() {
+			item.FileExtension = path.Ext(fi.Name())
+			item.Size = fi.Size()
+			if isMarkdownFile(fi.Name()) {
+				meta := md.getDocMeta(fsys, filename, fi)
+				item.Title, item.Summary, item.Weight = meta.title, meta.summary, meta.weight
+				if meta.access != nil {
+					access[name] = meta.access
+				}
+			}
+		} else {
+			item.Href = item.Href + "/"
+		}
+		item.Icon = template.GetExtensionsIcon(item.FileExtension, fi.IsDir())
+		items = append(items, item)
+	}
+	return items, access
+}
+
+// setDirWeights 设置目录条目的weight(目录index文件元数据中的)
+func (md *Markdown) setDirWeights(fsys fs.FS, listDir string, items []convert.TemplateFileItemData) {
+	for i := range items {
+		if items[i].IsFile {
+			continue
+		}
+		if index, ok := md.findIndex(fsys, path.Join(listDir, items[i].Name)); ok {
+			if info, err := fs.Stat(fsys, index); err == nil {
+				items[i].Weight = md.getDocMeta(fsys, index, info).weight
+			}
+		}
+	}
+}
+
+// setChildCounts 设置目录条目中可见的子条目数
+func (md *Markdown) setChildCounts(fsys fs.FS, listDir string, items []convert.TemplateFileItemData) {
+	for i := range items {
+		if items[i].IsFile {
+			continue
+		}
+		for _, child := range md.listdir(fsys, path.Join(listDir, items[i].Name)) {
+			if child != nil && !isHiddenName(child.Name()) {
+				items[i].ChildCount++
+			}
+		}
+	}
+}
+
+// getDocMeta 读取markdown文件元数据, 按修改时间和大小缓存
+func (md *Markdown) getDocMeta(fsys fs.FS, filename string, info fs.FileInfo) docMeta {
+	key := fileKey{fsys: fsys, name: filename}
+	if v, ok := md.metaCache.Load(key); ok {
+		meta := v.(docMeta)
+		if meta.modTime.Equal(info.ModTime()) && meta.size == info.Size() {
+			return meta
+		}
+	}
+	meta := docMeta{modTime: info.ModTime(), size: info.Size()}
+	file, err := fsys.Open(filename)
+	if err != nil {
+		return meta
+	}
+	defer file.Close()
+	src, err := readFrontMatter(file)
+	if err != nil {
+		return meta
+	}
+	metaData, err := convert.ParseFrontMatter(src)
+	meta.access = metaAccess(metaData, err)
+	meta.title = convert.MetaString(metaData, "Title", "title")
+	meta.summary = convert.MetaString(metaData, "Summary", "summary", "Description", "description")
+	meta.author = convert.MetaString(metaData, "Author", "author")
+	meta.draft = isDraft(metaData)
+	if weight, err := strconv.ParseFloat(convert.MetaString(metaData, "Weight", "weight", "Order", "order"), 64); err == nil {
+		meta.weight = int(weight)
+	}
+	md.metaCache.Store(key, meta)
+	return meta
+}
+
+// readFrontMatter 只读取文件开头的元数据块(最多 frontMatterMaxBytes), 没有元数据时只读取第一行.
+// 目录列表的权限检查需要每个markdown文件的元数据, 不能读取整个文件
+func readFrontMatter(file io.Reader) ([]byte, error) {
+	br := bufio.NewReaderSize(io.LimitReader(file, frontMatterMaxBytes), 512)
+	line, err := br.ReadBytes('\n')
+	if string(bytes.TrimSpace(bytes.TrimPrefix(line, []byte("\xef\xbb\xbf")))) != "---" {
+		if err == io.EOF {
+			err = nil
+		}
+		return nil, err
+	}
+	src := line
+	for err == nil {
+		line, err = br.ReadBytes('\n')
+		src = append(src, line...)
+		if trimmed := string(bytes.TrimSpace(line)); trimmed == "---" || trimmed == "..." {
+			return src, nil
+		}
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	return src, err
+}
+
+// sortItems 目录始终排在文件前面, 然后按指定字段排序
+func sortItems(items []convert.TemplateFileItemData, sortBy, order string) {
+	desc := order == sortOrderDesc
+	less := func(a, b convert.TemplateFileItemData) bool {
+		switch sortBy {
+		case sortByMtime:
+			if !a.ModTime.Equal(b.ModTime) {
+				return a.ModTime.Before(b.ModTime) != desc
+			}
+		case sortBySize:
+			if a.Size != b.Size {
+				return (a.Size < b.Size) != desc
+			}
+		case sortByTitle:
+			at, bt := itemTitle(a), itemTitle(b)
+			if at != bt {
+				return (at < bt) != desc
+			}
+		}
+		an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name)
+		if sortBy == sortByName && an != bn {
+			return (an < bn) != desc
+		}
+		return an < bn
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		// 1. IsFile:升序排序
+		if items[i].IsFile != items[j].IsFile {
+			return items[j].IsFile
+		}
+		// 2. 按字段排序
+		return less(items[i], items[j])
+	})
+}
+
+func itemTitle(item convert.TemplateFileItemData) string {
+	if item.Title != "" {
+		return strings.ToLower(item.Title)
+	}
+	return strings.ToLower(item.Name)
+}
+
+// listOptions 从请求参数中读取排序和分页参数
+func (md *Markdown) listOptions(r *http.Request) (sortBy, order string, page int) {
+	query := r.URL.Query()
+	sortBy = strings.ToLower(query.Get("sort"))
+	switch sortBy {
+	case sortByName, sortByMtime, sortBySize, sortByTitle, sortByWeight:
+	default:
+		sortBy = sortByWeight
+	}
+	order = strings.ToLower(query.Get("order"))
+	if order != sortOrderDesc {
+		order = sortOrderAsc
+	}
+	page, _ = strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	return
+}
+
+// listDirectory 填充目录列表数据, 包括排序和分页
+func (md *Markdown) listDirectory(r *http.Request, fsys fs.FS, data *convert.TemplateData, root, listDir string) {
+	data.Sort, data.Order, data.Page = md.listOptions(r)
+	items := md.listItems(r, fsys, root, listDir)
+	if data.Sort == sortByWeight {
+		// 按weight排序时需要所有目录的weight, 其它排序只读取当前页的目录
+		md.setDirWeights(fsys, listDir, items)
+		md.orderItems(fsys, listDir, items)
+	} else {
+		sortItems(items, data.Sort, data.Order)
+	}
+
+	data.TotalItems = len(items)
+	data.PageSize = md.PageSize
+	data.PageCount = 1
+	if md.PageSize > 0 && len(items) > md.PageSize {
+		data.PageCount = (len(items) + md.PageSize - 1) / md.PageSize
+		if data.Page > data.PageCount {
+			data.Page = data.PageCount
+		}
+		start := (data.Page - 1) * md.PageSize
+		end := start + md.PageSize
+		if end > len(items) {
+			end = len(items)
+		}
+		items = items[start:end]
+	} else {
+		data.Page = 1
+	}
+	if data.Sort != sortByWeight {
+		md.setDirWeights(fsys, listDir, items)
+	}
+	md.setChildCounts(fsys, listDir, items)
+	data.CurrentDirs = items
+}
@@ -0,0 +1,99 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+func TestListDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a/README.md": "---\nweight: 3\n---\n# A\n",
+		"b/README.md": "---\nweight: 1\n---\n# B\n",
+		"b/x.md":      "# X\n",
+		"c/README.md": "---\nweight: 2\n---\n# C\n",
+		"c/x.md":      "# X\n",
+		"c/y.md":      "# Y\n",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.PageSize = 2
+	})
+	fsys := md.unwrapFS(md.fsmap.Default())
+
+	tests := []struct {
+		query  string
+		names  []string
+		counts []int
+	}{
+		{"?sort=name", []string{"a", "b"}, []int{1, 2}},
+		{"?sort=name&page=2", []string{"c"}, []int{3}},
+		{"?sort=name&order=desc", []string{"c", "b"}, []int{3, 2}},
+		{"", []string{"b", "c"}, []int{2, 3}},
+		{"?page=2", []string{"a"}, []int{1}},
+	}
+	for _, tt := range tests {
+		data := &convert.TemplateData{}
+		md.listDirectory(newTestRequest(root, "/"+tt.query, ""), fsys, data, root, root)
+		if data.TotalItems != 3 {
+			t.Errorf("%q: TotalItems = %d, want 3", tt.query, data.TotalItems)
+		}
+		if len(data.CurrentDirs) != len(tt.names) {
+			t.Errorf("%q: got %d items, want %v", tt.query, len(data.CurrentDirs), tt.names)
+			continue
+		}
+		for i, item := range data.CurrentDirs {
+			if item.Name != tt.names[i] || item.ChildCount != tt.counts[i] {
+				t.Errorf("%q: item %d = %s (%d children), want %s (%d children)", tt.query, i, item.Name, item.ChildCount, tt.names[i], tt.counts[i])
+			}
+		}
+	}
+}
+
+// shortReader 记录读取的字节数
+type shortReader struct {
+	r    *strings.Reader
+	read int
+}
+
+func (s *shortReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.read += n
+	return n, err
+}
+
+func TestReadFrontMatter(t *testing.T) {
+	body := strings.Repeat("body text\n", 1000)
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		maxRead int
+	}{
+		{"none", "# Title\n" + body, "", 512},
+		{"empty", "", "", 0},
+		{"front matter", "---\ntitle: A\n---\n" + body, "---\ntitle: A\n---\n", 512},
+		{"dots", "---\ntitle: A\n...\n" + body, "---\ntitle: A\n...\n", 512},
+		{"bom", "\xef\xbb\xbf---\ntitle: A\n---\n" + body, "\xef\xbb\xbf---\ntitle: A\n---\n", 512},
+		{"crlf", "---\r\ntitle: A\r\n---\r\n" + body, "---\r\ntitle: A\r\n---\r\n", 512},
+		{"not closed", "---\ntitle: A\n", "---\ntitle: A\n", 512},
+		{"thematic break", "----\n" + body, "", 512},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &shortReader{r: strings.NewReader(tt.src)}
+			got, err := readFrontMatter(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("readFrontMatter() = %q, want %q", got, tt.want)
+			}
+			if r.read > tt.maxRead {
+				t.Errorf("read %d bytes, want at most %d", r.read, tt.maxRead)
+			}
+		})
+	}
+}
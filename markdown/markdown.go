@@ -2,24 +2,19 @@ package markdown
 
 import (
 	"bytes"
-	"encoding/json"
+	"fmt"
 	"io"
 	"io/fs"
-	"log"
 	"net/http"
-	"os"
 	"path"
 	"path/filepath"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/caddyserver/caddy/v2"
 	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
 	"github.com/kingreatwill/caddy-modules/markdown/convert"
-	"github.com/kingreatwill/caddy-modules/markdown/git"
 	"github.com/kingreatwill/caddy-modules/markdown/template"
 	"go.uber.org/zap"
 )
@@ -32,11 +27,92 @@ type Markdown struct {
 	// The names of files to try as index files if a folder is requested.
 	// Default: index.html index.htm
 	IndexNames []string `json:"index,omitempty"`
-	engine     *convert.MarkdownConvert
-	fileSystem fs.FS
-	logger     *zap.Logger
-
-	gitMap sync.Map
+	// The number of entries per page of a directory listing.
+	// Default: 1000, negative disables pagination.
+	PageSize int `json:"page_size,omitempty"`
+	// The name of the filesystem to read files from, registered with
+	// the global `filesystem` option (caddy.fs modules).
+	// Default: {http.vars.fs}, the same as file_server.
+	FileSystem string `json:"fs,omitempty"`
+	// Serve versioned documentation from git tags and branches.
+	Versions *Versions `json:"versions,omitempty"`
+	// Keep the git repository of the root up to date with its remote.
+	Sync *Sync `json:"sync,omitempty"`
+	// The languages of document variants: the first is the language of
+	// name.md, the others are served from name.<lang>.md.
+	Languages []string `json:"languages,omitempty"`
+	// The directory to persist the git history index in, "off" keeps
+	// it in memory only. Default: <caddy data dir>/markdown/git
+	GitIndexDir string `json:"git_index_dir,omitempty"`
+	// Restrict paths to authenticated users and groups.
+	Access *Access `json:"access,omitempty"`
+	// How heading IDs are generated: unicode keeps CJK characters,
+	// pinyin transliterates Chinese, ascii drops non-ASCII characters.
+	// Default: unicode
+	HeadingIDs string `json:"heading_ids,omitempty"`
+	// The symbol of the permalink anchor rendered after each heading,
+	// empty disables permalinks.
+	Permalinks string `json:"permalinks,omitempty"`
+	// The reading speed used to estimate the reading time: words per
+	// minute, and CJK characters per minute. Default: 200, 400
+	WordsPerMinute    int `json:"words_per_minute,omitempty"`
+	CJKCharsPerMinute int `json:"cjk_chars_per_minute,omitempty"`
+	// Resize and re-encode images on the fly (?w=800&fmt=webp).
+	Images *Images `json:"images,omitempty"`
+	// The directory of shortcode templates (<name>.html, or
+	// <name>.paired.html for shortcodes with an end tag), relative to the
+	// directory of the template file, or to the root with a built-in
+	// template or a leading slash. They add to or override the built-in
+	// shortcodes.
+	Shortcodes string `json:"shortcodes,omitempty"`
+	// Build a navigation tree of the whole site for TemplateData.Tree.
+	Tree *NavTree `json:"tree,omitempty"`
+	// The scheme and host of the site used for canonical URLs, Open
+	// Graph and JSON-LD, placeholders are supported. Without it no
+	// absolute URLs are emitted
+	SiteURL string `json:"site_url,omitempty"`
+	// Serve /robots.txt that keeps crawlers away from hidden, draft and
+	// private pages, and mark those pages with X-Robots-Tag: noindex.
+	Robots *Robots `json:"robots,omitempty"`
+	// Cache rendered pages in memory together with their brotli, zstd
+	// and gzip variants, which are served by Accept-Encoding.
+	Cache *RenderCache `json:"cache,omitempty"`
+	// Rendered output larger than this many bytes is streamed instead of
+	// buffered, without an ETag and not cached. Negative always buffers.
+	// Default: 4MiB
+	StreamThreshold int64 `json:"stream_threshold,omitempty"`
+	// The file names of error pages looked up from the requested
+	// directory upwards, by status code ("404") or "*" for any other
+	// status. Default: _<status>.md, then _error.md
+	ErrorPages map[string]string `json:"error_pages,omitempty"`
+	// Render .csv and .tsv files as sortable tables in the page template.
+	CSV *CSVTables `json:"csv,omitempty"`
+	// Concatenate all documents beneath a directory into one printable
+	// page when it is requested with ?print.
+	Print *PrintView `json:"print,omitempty"`
+	// List the most recently changed documents of a directory with
+	// ?recent, and in TemplateData.Recent of directory pages.
+	Recent *RecentChanges `json:"recent,omitempty"`
+
+	engine      *convert.MarkdownConvert
+	fsmap       caddy.FileSystems
+	logger      *zap.Logger
+	renderCache *renderCache
+
+	gitMap    sync.Map
+	metaCache sync.Map
+	repoCache sync.Map
+	indexes   sync.Map
+
+	shortcodeCache sync.Map
+	imageSizes     sync.Map
+	orderCache     sync.Map
+	treeCache      sync.Map
+	versionCache   sync.Map
+	// 目录下需要权限的文件, 见 restrictedUnder
+	restrictedCache sync.Map
+	// 注册的文件系统 -> 解包后的文件系统, 见 unwrapFS
+	filesystems sync.Map
 }
 
 var bufPool = sync.Pool{
@@ -45,14 +121,28 @@ var bufPool = sync.Pool{
 	},
 }
 
+// 超过maxPooledBuffer的缓冲区不放回bufPool, 避免偶尔的大页面让池中的缓冲区一直占用内存
+const maxPooledBuffer = 1 << 20
+
+func getBuffer() *bytes.Buffer {
+	buf := bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufPool.Put(buf)
+	}
+}
+
 func (Markdown) CaddyModule() caddy.ModuleInfo {
 	return caddy.ModuleInfo{
 		ID: "http.handlers.markdown",
 		New: func() caddy.Module {
 			return &Markdown{
-				engine:     convert.New(),
-				fileSystem: osFS{},
-				gitMap:     sync.Map{},
+				engine: convert.New(),
+				gitMap: sync.Map{},
 			}
 		},
 	}
@@ -61,15 +151,53 @@ func (Markdown) CaddyModule() caddy.ModuleInfo {
 // Provision sets up the module. #caddy.Provisioner
 func (md *Markdown) Provision(ctx caddy.Context) error {
 	md.logger = ctx.Logger()
+	md.fsmap = ctx.Filesystems()
+	if md.FileSystem == "" {
+		md.FileSystem = "{http.vars.fs}"
+	}
 	if md.Root == "" {
 		md.Root = "{http.vars.root}"
 	}
+	if err := md.provisionFS(ctx); err != nil {
+		return err
+	}
 	if md.MIMETypes == nil {
 		md.MIMETypes = []string{"text/markdown"}
 	}
 	if md.IndexNames == nil {
 		md.IndexNames = defaultIndexNames
 	}
+	if md.PageSize == 0 {
+		md.PageSize = defaultPageSize
+	}
+	if md.GitIndexDir == "" {
+		md.GitIndexDir = filepath.Join(caddy.AppDataDir(), "markdown", "git")
+	}
+	if err := validErrorPages(md.ErrorPages); err != nil {
+		return err
+	}
+	if !convert.ValidHeadingIDs(md.HeadingIDs) {
+		return fmt.Errorf("unsupported heading_ids '%s'", md.HeadingIDs)
+	}
+	opts := []convert.Option{convert.WithHeadingIDs(md.HeadingIDs), convert.WithPermalinks(md.Permalinks),
+		convert.WithReadingSpeed(md.WordsPerMinute, md.CJKCharsPerMinute)}
+	if md.CSV != nil {
+		opts = append(opts, convert.WithCSVMaxRows(md.CSV.MaxRows))
+	}
+	md.engine = convert.New(opts...)
+	md.provisionVersions()
+	if err := md.provisionAccess(); err != nil {
+		return err
+	}
+	if err := md.provisionImages(ctx); err != nil {
+		return err
+	}
+	if err := md.provisionCache(); err != nil {
+		return err
+	}
+	if err := md.provisionSync(ctx); err != nil {
+		return err
+	}
 	// for hide paths that are static (i.e. no placeholders), we can transform them into
 	// absolute paths before the server starts for very slight performance improvement
 	for i, h := range md.Hide {
@@ -110,12 +238,88 @@ func (md *Markdown) ServeHTTP(w http.ResponseWriter, r *http.Request, next caddy
 	// 	zap.String("filename", filename),
 	// 	zap.Bool("IsDir", info.IsDir()))
 
-	buf := bufPool.Get().(*bytes.Buffer)
-	buf.Reset()
-	defer bufPool.Put(buf)
+	if md.isWebhook(r) {
+		return md.serveWebhook(w, r)
+	}
+	md.rememberSyncRoot(r)
+	if md.Robots != nil && r.URL.Path == robotsPath {
+		return md.serveRobots(w, r)
+	}
 
+	format := requestFormat(r)
+	w.Header().Add("Vary", "Accept")
+	if format == formatHTML {
+		// 使用 _404.md 等渲染错误页面, r 为处理版本和语言后的请求
+		defer func() {
+			if err != nil {
+				err = md.serveError(w, r, err)
+			}
+		}()
+	}
+
+	// 多版本文档直接从版本的文件树中读取
+	versioned, err := md.withVersion(r)
+	if err != nil {
+		return err
+	}
+	r = md.withLanguage(w, versioned)
+
+	// 按路径的访问控制, 文档元数据中的access在渲染前检查
+	if !md.canView(r, r.URL.Path, nil) {
+		return md.denied(w, r)
+	}
+	if md.restricted(r.URL.Path, nil) {
+		setPrivate(w)
+	}
+	if md.Robots != nil && md.noindex(r, r.URL.Path) {
+		setNoindex(w)
+	}
+
+	// 热力图的提交统计
+	if r.URL.Query().Has("activity") {
+		return md.serveActivity(w, r)
+	}
+	// 最近修改的文档
+	if md.Recent != nil && r.URL.Query().Has("recent") {
+		return md.serveRecent(w, r, format)
+	}
+	// 缩放和转换图片
+	if md.isImageRequest(r) {
+		return md.serveImage(w, r, next)
+	}
+	// 目录的打印页面
+	if ok, err := md.servePrint(w, r, format); ok {
+		return err
+	}
+	if versionFromRequest(r) != nil {
+		return md.serveVersion(w, r, format, next)
+	}
+
+	// 其它语言的版本直接从文件系统中读取
+	if fsys, filename, ok := md.languageVariant(r); ok {
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return statusError(err)
+		}
+		return md.serveMarkdown(w, r, http.StatusOK, string(content), format)
+	}
+
+	// 没有索引文件的目录直接返回目录数据
+	if format == formatJSON && md.isBareDir(r) {
+		data, err := md.getTemplateData(r)
+		if err != nil {
+			return statusError(err)
+		}
+		return md.writeJSON(w, r, http.StatusOK, data, "")
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	// csv/tsv文件渲染为表格, ?format=markdown 时返回原始文件
+	isTable := md.isTableFile(r.URL.Path) && format != formatMarkdown
 	shouldBuf := func(status int, header http.Header) bool {
-		if strings.HasSuffix(r.URL.Path, ".md") || strings.HasSuffix(r.URL.Path, ".markdown") {
+		if strings.HasSuffix(r.URL.Path, ".md") || strings.HasSuffix(r.URL.Path, ".markdown") || isTable {
 			return true
 		}
 		ct := header.Get("Content-Type")
@@ -134,123 +338,206 @@ func (md *Markdown) ServeHTTP(w http.ResponseWriter, r *http.Request, next caddy
 	if !rec.Buffered() {
 		return nil
 	}
-
-	inputStr := buf.String()
-	// template
-	tmpl, ok := template.Templates[md.Template]
-	if !ok {
-		// if not a built-in template, try as resource file
-		buf.Reset()
-		root := md.getRoot(r)
-		fs := http.Dir(root)
-		file, err := fs.Open(md.Template)
+	if isTable {
+		table, err := md.tableMarkdown(r.URL.Path, buf.Bytes())
 		if err != nil {
-			md.logger.Error("template error:", zap.String("root", root), zap.String("Template", md.Template), zap.Error(err))
-		}
-		if err == nil {
-			defer file.Close()
-			io.Copy(buf, file)
+			return caddyhttp.Error(http.StatusUnprocessableEntity, err)
 		}
-		if buf.Len() > 0 {
-			tmpl = buf.String()
-		} else {
-			tmpl = "{{.MdHtml}}"
+		return md.serveMarkdown(w, r, rec.Status(), table, format)
+	}
+
+	return md.serveMarkdown(w, r, rec.Status(), buf.String(), format)
+}
+
+// serveMarkdown 按请求的格式返回markdown源文件、json数据或者渲染后的html
+func (md *Markdown) serveMarkdown(w http.ResponseWriter, r *http.Request, status int, inputStr, format string) error {
+	metaData, err := convert.ParseFrontMatter([]byte(inputStr))
+	if err != nil {
+		md.logger.Warn("parsing front matter", zap.String("path", r.URL.Path), zap.Error(err))
+	}
+	access := metaAccess(metaData, err)
+	if !md.canView(r, r.URL.Path, access) {
+		return md.denied(w, r)
+	}
+	if len(access) > 0 {
+		setPrivate(w)
+	}
+	if md.Robots != nil && (len(access) > 0 || isDraft(metaData)) {
+		setNoindex(w)
+	}
+
+	// 原样返回markdown源文件
+	if format == formatMarkdown {
+		return md.writeResponse(w, r, status, []byte(inputStr), "text/markdown; charset=utf-8")
+	}
+
+	// 渲染缓存
+	var key string
+	if md.renderCache != nil && (status == 0 || status == http.StatusOK) {
+		key = md.cacheKey(r, inputStr, format)
+		if e := md.cached(key); e != nil {
+			return md.writeCached(w, r, e)
 		}
 	}
+
 	// render markdown
-	html, err := md.renderMarkdown(r, inputStr, tmpl)
+	data, err := md.renderData(r, inputStr)
 	if err != nil {
-		return caddyhttp.Error(http.StatusInternalServerError, err)
+		return statusError(err)
 	}
+	if format == formatJSON {
+		return md.writeJSON(w, r, status, data, key)
+	}
+	return md.writeHTML(w, r, status, data, key)
+}
 
-	buf.Reset()
-	buf.WriteString(html)
-	rec.Header().Set("Content-Type", "text/html; charset=utf-8")
-	rec.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
-	rec.Header().Del("Accept-Ranges") // we don't know ranges for dynamically-created content
-	rec.Header().Del("Last-Modified") // useless for dynamic content since it's always changing
-	// we don't know a way to quickly generate etag for dynamic content,
-	// and weak etags still cause browsers to rely on it even after a
-	// refresh, so disable them until we find a better way to do this
-	rec.Header().Del("Etag")
-	return rec.WriteResponse()
+// writeHTML 使用模板渲染html, key不为空时放入渲染缓存
+func (md *Markdown) writeHTML(w http.ResponseWriter, r *http.Request, status int, data *convert.TemplateData, key string) error {
+	tmpl := md.getTemplate(r)
+	if data.Slides {
+		tmpl = template.Slides
+	} else if data.Print {
+		tmpl = template.Print
+	}
+	return md.writeRendered(w, r, status, "text/html; charset=utf-8", key, data, func(out io.Writer) error {
+		return template.Execute(out, tmpl, data)
+	})
+}
+
+// getTemplate 返回内置模板或者root下的模板文件
+func (md *Markdown) getTemplate(r *http.Request) string {
+	if tmpl, ok := template.Templates[md.Template]; ok {
+		return tmpl
+	}
+	// if not a built-in template, try as resource file
+	root := md.getSiteRoot(r)
+	fsys, err := md.getSiteFS(r)
+	if err != nil {
+		md.logger.Error("template error:", zap.String("root", root), zap.String("Template", md.Template), zap.Error(err))
+		return "{{.MdHtml}}"
+	}
+	file, err := fsys.Open(caddyhttp.SanitizedPathJoin(root, md.Template))
+	if err != nil {
+		md.logger.Error("template error:", zap.String("root", root), zap.String("Template", md.Template), zap.Error(err))
+		return "{{.MdHtml}}"
+	}
+	defer file.Close()
+	tmpl, err := io.ReadAll(file)
+	if err != nil || len(tmpl) == 0 {
+		return "{{.MdHtml}}"
+	}
+	return string(tmpl)
 }
 
-func (md *Markdown) renderMarkdown(r *http.Request, inputStr, tmplStr string) (string, error) {
+// renderData 获取目录数据并转换markdown
+func (md *Markdown) renderData(r *http.Request, inputStr string) (*convert.TemplateData, error) {
 	// 获取目录数据
 	data, err := md.getTemplateData(r)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	// 展开包含的其它文件
+	if data.CurrentIsFile {
+		inputStr, data.Includes = md.expandIncludes(r, data.CurrentFile, inputStr)
 	}
 	// 转换
-	err = md.engine.Convert(inputStr, data)
+	opts := []convert.ConvertOption{convert.WithShortcodes(md.getShortcodes(r))}
+	if md.Images != nil && md.Images.Srcset && data.CurrentIsFile {
+		if resolve := md.imageResolver(r, data.CurrentFile); resolve != nil {
+			opts = append(opts, convert.WithImages(resolve))
+		}
+	}
+	if data.CurrentIsFile && isSlides(r, inputStr) {
+		opts = append(opts, convert.WithSlides())
+		data.Slides = true
+	}
+	err = md.engine.Convert(inputStr, data, opts...)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if data.Title == "" {
 		orignalRequest := r.Context().Value(caddyhttp.OriginalRequestCtxKey).(http.Request)
 		data.Title = path.Base(orignalRequest.URL.Path)
 	}
-	buf := bufPool.Get().(*bytes.Buffer)
-	buf.Reset()
-	defer bufPool.Put(buf)
-	// 解析模板
-	err = template.Execute(buf, tmplStr, data)
-	if err != nil {
-		return "", err
+	md.setSEO(r, data)
+	return data, nil
+}
+
+// isSlides 请求参数有slides或者元数据layout为slides时按幻灯片显示
+func isSlides(r *http.Request, inputStr string) bool {
+	if r.URL.Query().Has("slides") {
+		return true
 	}
-	return buf.String(), nil
+	return convert.MetaString(convert.FrontMatter([]byte(inputStr)), "Layout", "layout") == "slides"
 }
 
-func (md *Markdown) getRoot(r *http.Request) string {
-	repl := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
-	return repl.ReplaceAll(md.Root, ".")
+func (md *Markdown) writeJSON(w http.ResponseWriter, r *http.Request, status int, data *convert.TemplateData, key string) error {
+	return md.writeRendered(w, r, status, "application/json; charset=utf-8", key, data, func(out io.Writer) error {
+		return encodeTemplateData(out, data)
+	})
 }
 
-func (md *Markdown) getGitStats(gitRoot string) (string, string) {
-	startDate := time.Now().AddDate(0, -11, 0).Format("2006-01-02")
-	data := make(map[string]int64)
-	dv, ok := md.gitMap.Load(startDate)
-	if ok {
-		data = dv.(map[string]int64)
-	} else {
-		data = git.GitStats(gitRoot)
-		md.gitMap.Store(startDate, data)
-		// 删除上一天的缓存
-		preDate := time.Now().AddDate(0, -11, -1).Format("2006-01-02")
-		md.gitMap.Delete(preDate)
-	}
-
-	dataArr := []struct {
-		Date  string `json:"date"`
-		Value int64  `json:"value"`
-	}{}
-	for k, v := range data {
-		dataArr = append(dataArr, struct {
-			Date  string `json:"date"`
-			Value int64  `json:"value"`
-		}{
-			Date:  k,
-			Value: v,
-		})
-	}
-	databytes, err := json.Marshal(dataArr)
-	if err != nil {
-		log.Println("json error", err)
-		return startDate, "[]"
+// writeResponse 写入动态生成的内容, 根据内容和包含的文件生成ETag并处理 If-None-Match
+func (md *Markdown) writeResponse(w http.ResponseWriter, r *http.Request, status int, body []byte, contentType string, deps ...convert.IncludeFile) error {
+	header := w.Header()
+	dynamicHeaders(header)
+	if status == 0 {
+		status = http.StatusOK
 	}
-	return startDate, string(databytes)
+	header.Del("Etag")
+	if status == http.StatusOK {
+		tag := etag(body, deps...)
+		header.Set("Etag", tag)
+		if etagMatch(r, tag) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+	}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	_, err := w.Write(body)
+	return err
+}
+
+// dynamicHeaders 清除文件服务设置的、不适用于动态内容的响应头
+func dynamicHeaders(header http.Header) {
+	header.Del("Accept-Ranges") // we don't know ranges for dynamically-created content
+	header.Del("Last-Modified") // useless for dynamic content since it's always changing
+	if header.Get("Cache-Control") == "" {
+		// 内容随文件和目录变化, 每次都需要重新验证
+		header.Set("Cache-Control", "no-cache")
+	}
+}
+
+func (md *Markdown) getRoot(r *http.Request) string {
+	if versionFromRequest(r) != nil {
+		return "."
+	}
+	return md.getSiteRoot(r)
+}
+
+// getSiteRoot 返回配置的根目录
+func (md *Markdown) getSiteRoot(r *http.Request) string {
+	repl := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
+	return repl.ReplaceAll(md.Root, ".")
 }
 
 func (md *Markdown) getTemplateData(r *http.Request) (data *convert.TemplateData, err error) {
 	data = &convert.TemplateData{
 		CurrentDirs: []convert.TemplateFileItemData{},
 	}
+	fsys, err := md.getFS(r)
+	if err != nil {
+		return nil, err
+	}
 	root := md.getRoot(r)
-	data.GitStartDate, data.GitStatsData = md.getGitStats(root)
 	filename := strings.TrimSuffix(caddyhttp.SanitizedPathJoin(root, r.URL.Path), "/")
+	data.GitStartDate, data.GitStatsData = md.getGitStats(r, fsys, root, filename)
 
-	info, err := fs.Stat(md.fileSystem, filename)
+	info, err := fs.Stat(fsys, filename)
 	if err != nil {
 		return nil, err
 	}
@@ -276,80 +563,72 @@ func (md *Markdown) getTemplateData(r *http.Request) (data *convert.TemplateData
 
 	if !info.IsDir() {
 		listDir = filepath.Dir(filename)
+	} else if index, ok := md.findIndex(fsys, listDir); ok {
+		data.CurrentFile = index
+		data.CurrentIsFile = true
 	}
-	for _, fi := range md.listdir(listDir) {
-		if strings.HasPrefix(fi.Name(), ".") || strings.HasPrefix(fi.Name(), "_") {
-			continue
-		}
-		item := convert.TemplateFileItemData{
-			Name:          fi.Name(),
-			IsFile:        !fi.IsDir(),
-			FileExtension: fi.Name(),
-			Href:          strings.ReplaceAll(path.Join(listDir, fi.Name()), "\\", "/"),
+	if data.CurrentIsFile {
+		data.CurrentFile = md.languageFile(fsys, data.CurrentFile, languageFromRequest(r))
+	}
+	md.listDirectory(r, fsys, data, root, listDir)
+	md.setTree(r, fsys, data, root, info.IsDir())
+	if md.Recent != nil && info.IsDir() {
+		urlDir := strings.TrimSuffix(path.Clean("/"+r.URL.Path), "/") + "/"
+		data.Recent, _ = md.recentDocs(r, fsys, root, urlDir, md.recentCount(r))
+	}
+	md.setVersions(r, data)
+	md.setLanguages(r, fsys, data)
+
+	// 版本中的链接需要加上版本前缀
+	if v := versionFromRequest(r); v != nil && v.prefix != "" {
+		if data.UpperPath != "" {
+			data.UpperPath = v.prefix + data.UpperPath
 		}
-		if !fi.IsDir() {
-			item.FileExtension = path.Ext(fi.Name())
-			if info.IsDir() {
-				for _, index := range md.IndexNames {
-					if index == fi.Name() {
-						data.CurrentFile = item.Href
-						data.CurrentIsFile = true
-					}
-				}
-			}
-		} else {
-			item.Href = item.Href + "/"
+		for i := range data.CurrentDirs {
+			data.CurrentDirs[i].Href = v.prefix + data.CurrentDirs[i].Href
 		}
-		if strings.HasPrefix(item.Href, root) {
-			item.Href = strings.Replace(item.Href, root, "", 1)
+		for i := range data.Languages {
+			data.Languages[i].Href = v.prefix + data.Languages[i].Href
 		}
-		if !strings.HasPrefix(item.Href, "/") {
-			item.Href = "/" + item.Href
+		prefixTree(data.Tree, v.prefix)
+		for _, link := range []*convert.PageLink{data.Prev, data.Next} {
+			if link != nil {
+				link.Href = v.prefix + link.Href
+			}
 		}
-		//item.Icon = template.GetExtensionsIcon(item.FileExtension, fi.IsDir())
-		data.CurrentDirs = append(data.CurrentDirs, item)
 	}
-	sort.Slice(data.CurrentDirs, func(i, j int) bool {
-		// 1. IsFile:升序排序
-		if data.CurrentDirs[i].IsFile != data.CurrentDirs[j].IsFile {
-			return data.CurrentDirs[j].IsFile
-		}
-		// 2. Name:升序排序
-		return strings.ToLower(data.CurrentDirs[i].Name) < strings.ToLower(data.CurrentDirs[j].Name)
-	})
 	return
 }
 
-func (md *Markdown) listdir(pathname string) (fileInfos []fs.FileInfo) {
-	dirEntries, err := os.ReadDir(pathname)
+// isBareDir 请求的路径是否是没有索引文件的目录
+func (md *Markdown) isBareDir(r *http.Request) bool {
+	fsys, err := md.getFS(r)
 	if err != nil {
-		log.Println(err)
-		return nil
+		return false
 	}
-	for _, de := range dirEntries {
-		fi, _ := de.Info()
-		fileInfos = append(fileInfos, fi)
+	dir := strings.TrimSuffix(caddyhttp.SanitizedPathJoin(md.getRoot(r), r.URL.Path), "/")
+	if info, err := fs.Stat(fsys, dir); err != nil || !info.IsDir() {
+		return false
 	}
-	return
+	_, ok := md.findIndex(fsys, dir)
+	return !ok
 }
 
-// osFS is a simple fs.FS implementation that uses the local
-// file system. (We do not use os.DirFS because we do our own
-// rooting or path prefixing without being constrained to a single
-// root folder. The standard os.DirFS implementation is problematic
-// since roots can be dynamic in our application.)
-//
-// osFS also implements fs.StatFS, fs.GlobFS, fs.ReadDirFS, and fs.ReadFileFS.
-type osFS struct{}
-
-func (osFS) Open(name string) (fs.File, error)          { return os.Open(name) }
-func (osFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
-func (osFS) Glob(pattern string) ([]string, error)      { return filepath.Glob(pattern) }
-func (osFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
-func (osFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+// findIndex 返回目录dir中的索引文件
+func (md *Markdown) findIndex(fsys fs.FS, dir string) (string, bool) {
+	for _, index := range md.IndexNames {
+		indexPath := strings.ReplaceAll(path.Join(dir, index), "\\", "/")
+		if fi, err := fs.Stat(fsys, indexPath); err == nil && !fi.IsDir() {
+			return indexPath, true
+		}
+	}
+	return "", false
+}
 
 var defaultIndexNames = []string{"README.md", "README.markdown", "readme.markdown", "readme.md"}
 
+const defaultPageSize = 1000
+
 const (
 	minBackoff, maxBackoff = 2, 5
 	separator              = string(filepath.Separator)
@@ -361,9 +640,4 @@ var (
 	_ caddy.Validator             = (*Markdown)(nil)
 	_ caddyhttp.MiddlewareHandler = (*Markdown)(nil)
 	// _ caddyfile.Unmarshaler       = (*Markdown)(nil)
-
-	_ fs.StatFS     = (*osFS)(nil)
-	_ fs.GlobFS     = (*osFS)(nil)
-	_ fs.ReadDirFS  = (*osFS)(nil)
-	_ fs.ReadFileFS = (*osFS)(nil)
 )
@@ -0,0 +1,97 @@
+package markdown
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+)
+
+// orderFileName 目录中指定阅读顺序的文件, 内容为文件名或目录名的列表(可以省略.md)
+const orderFileName = "_order.yml"
+
+// 遍历站点文档时最多进入的目录层数
+const maxWalkDepth = 32
+
+// dirOrder 缓存目录的 _order.yml
+type dirOrder struct {
+	modTime time.Time
+	size    int64
+	names   []string
+}
+
+// getDirOrder 读取目录的 _order.yml, 按修改时间和大小缓存
+func (md *Markdown) getDirOrder(fsys fs.FS, dir string) []string {
+	filename := path.Join(dir, orderFileName)
+	info, err := fs.Stat(fsys, filename)
+	if err != nil || info.IsDir() {
+		return nil
+	}
+	key := fileKey{fsys: fsys, name: filename}
+	if v, ok := md.orderCache.Load(key); ok {
+		order := v.(dirOrder)
+		if order.modTime.Equal(info.ModTime()) && order.size == info.Size() {
+			return order.names
+		}
+	}
+	order := dirOrder{modTime: info.ModTime(), size: info.Size()}
+	content, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	if err := yaml.Unmarshal(content, &names); err != nil {
+		md.logger.Error("order file error:", zap.String("file", filename), zap.Error(err))
+	}
+	for _, name := range names {
+		if name = strings.Trim(strings.TrimSpace(name), "/"); name != "" {
+			order.names = append(order.names, name)
+		}
+	}
+	md.orderCache.Store(key, order)
+	return order.names
+}
+
+// orderRank 返回名称在 _order.yml 中的位置, 没有列出时排在最后
+func orderRank(order []string, name string) int {
+	for i, n := range order {
+		if n == name || n == strings.TrimSuffix(name, path.Ext(name)) {
+			return i
+		}
+	}
+	return len(order)
+}
+
+// orderItems 按阅读顺序排序: _order.yml 中列出的条目在前, 然后是有weight的条目(从小到大), 最后目录在前按名称排序
+func (md *Markdown) orderItems(fsys fs.FS, dir string, items []convert.TemplateFileItemData) {
+	order := md.getDirOrder(fsys, dir)
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if ra, rb := orderRank(order, a.Name), orderRank(order, b.Name); ra != rb {
+			return ra < rb
+		}
+		if a.Weight != b.Weight {
+			// 没有weight(0)的排在后面
+			if a.Weight == 0 || b.Weight == 0 {
+				return b.Weight == 0
+			}
+			return a.Weight < b.Weight
+		}
+		if a.IsFile != b.IsFile {
+			return b.IsFile
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
+
+func pageTitle(title, name string) string {
+	if title != "" {
+		return title
+	}
+	return strings.TrimSuffix(name, path.Ext(name))
+}
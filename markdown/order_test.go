@@ -0,0 +1,77 @@
+package markdown
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+func TestGetDirOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"list", "- intro\n- guide/\n- faq.md\n", []string{"intro", "guide", "faq.md"}},
+		{"blank names", "- intro\n- ' '\n- /\n", []string{"intro"}},
+		{"not a list", "intro: 1\n", nil},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, map[string]string{"docs/_order.yml": tt.content})
+			md := newTestMarkdown(t, nil)
+			if got := md.getDirOrder(os.DirFS(root), "docs"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDirOrder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderRank(t *testing.T) {
+	order := []string{"intro", "guide", "faq.md"}
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"intro.md", 0},
+		{"intro", 0},
+		{"guide", 1},
+		{"faq.md", 2},
+		{"faq", 3},
+		{"other.md", 3},
+	}
+	for _, tt := range tests {
+		if got := orderRank(order, tt.name); got != tt.want {
+			t.Errorf("orderRank(%q) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOrderItems(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"docs/_order.yml": "- intro\n- api\n"})
+	md := newTestMarkdown(t, nil)
+	items := []convert.TemplateFileItemData{
+		{Name: "b.md", IsFile: true},
+		{Name: "a.md", IsFile: true},
+		{Name: "zoo"},
+		{Name: "heavy.md", IsFile: true, Weight: 20},
+		{Name: "api"},
+		{Name: "light.md", IsFile: true, Weight: 10},
+		{Name: "intro.md", IsFile: true},
+	}
+	md.orderItems(os.DirFS(root), "docs", items)
+
+	var got []string
+	for _, item := range items {
+		got = append(got, item.Name)
+	}
+	want := []string{"intro.md", "api", "light.md", "heavy.md", "zoo", "a.md", "b.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("orderItems() = %q, want %q", got, want)
+	}
+}
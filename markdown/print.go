@@ -0,0 +1,223 @@
+package markdown
+
+import (
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+// PrintView 目录加上 ?print 时按导航树的顺序把目录下的所有文档合并为一个页面, 用于打印和离线阅读.
+// 文档中的id加上文档的前缀, 链接到同一页面中其它文档的链接改为页面内的锚点.
+type PrintView struct {
+	// 最多合并的文档数, 其余的只显示数量. Default: 200
+	MaxDocs int `json:"max_docs,omitempty"`
+}
+
+const defaultPrintMaxDocs = 200
+
+// printDoc 打印页面中的一个文档
+type printDoc struct {
+	convert.PageLink
+	filename string
+	id       string // section的id, 文档中的id加上 id: 前缀
+	content  string
+}
+
+// printDir 请求是否是目录的打印页面, 返回目录在站点内的路径(以/结尾)
+func (md *Markdown) printDir(r *http.Request, fsys fs.FS, root string) (string, bool) {
+	if md.Print == nil || !r.URL.Query().Has("print") {
+		return "", false
+	}
+	info, err := fs.Stat(fsys, strings.TrimSuffix(caddyhttp.SanitizedPathJoin(root, r.URL.Path), "/"))
+	if err != nil || !info.IsDir() {
+		return "", false
+	}
+	return strings.TrimSuffix(path.Clean("/"+r.URL.Path), "/") + "/", true
+}
+
+// servePrint 返回目录的打印页面, 不是目录时返回false
+func (md *Markdown) servePrint(w http.ResponseWriter, r *http.Request, format string) (bool, error) {
+	if format == formatMarkdown {
+		return false, nil
+	}
+	fsys, err := md.getFS(r)
+	if err != nil {
+		return false, nil
+	}
+	root := md.getRoot(r)
+	urlDir, ok := md.printDir(r, fsys, root)
+	if !ok {
+		return false, nil
+	}
+	data, err := md.getTemplateData(r)
+	if err != nil {
+		return true, statusError(err)
+	}
+	docs := md.printDocs(r, fsys, root, urlDir)
+	more := 0
+	maxDocs := md.Print.MaxDocs
+	if maxDocs <= 0 {
+		maxDocs = defaultPrintMaxDocs
+	}
+	if len(docs) > maxDocs {
+		more = len(docs) - maxDocs
+		docs = docs[:maxDocs]
+	}
+	if err := md.renderPrint(r, data, docs); err != nil {
+		return true, err
+	}
+	if more > 0 {
+		data.MdHtml += `<p class="print-more">… ` + strconv.Itoa(more) + " more documents</p>\n"
+	}
+	data.Title = path.Base(urlDir)
+	if len(docs) > 0 && docs[0].Href == urlDir {
+		data.Title = data.Toc[0].Title
+	} else if urlDir == "/" {
+		data.Title = "Home"
+	}
+	data.Print = true
+	// 打印页面和各个文档的内容重复
+	if md.Robots != nil {
+		setNoindex(w)
+	}
+	if format == formatJSON {
+		return true, md.writeJSON(w, r, http.StatusOK, data, "")
+	}
+	return true, md.writeHTML(w, r, http.StatusOK, data, "")
+}
+
+// printDocs 按导航树的顺序返回目录urlDir下当前用户可以查看的文档
+func (md *Markdown) printDocs(r *http.Request, fsys fs.FS, root, urlDir string) []printDoc {
+	lang := languageFromRequest(r)
+	tree := md.getSiteTree(fsys, root, lang)
+	var docs []printDoc
+	ids := map[string]bool{}
+	for _, page := range md.treePages(r, tree) {
+		if !strings.HasPrefix(page.Href, urlDir) {
+			continue
+		}
+		filename, ok := md.pageFile(fsys, root, page.Href, lang)
+		if !ok {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			continue
+		}
+		if !md.canView(r, page.Href, metaAccess(convert.ParseFrontMatter(content))) {
+			continue
+		}
+		id := printID(page.Href)
+		for i := 1; ids[id]; i++ {
+			id = printID(page.Href) + "-" + strconv.Itoa(i)
+		}
+		ids[id] = true
+		docs = append(docs, printDoc{PageLink: page, filename: filename, id: id, content: string(content)})
+	}
+	return docs
+}
+
+// printID 由文档的路径生成section的id: /guide/install.md => guide-install
+func printID(href string) string {
+	if !strings.HasSuffix(href, "/") {
+		href = strings.TrimSuffix(href, path.Ext(href))
+	}
+	href = strings.Trim(href, "/")
+	id := strings.Map(func(c rune) rune {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '-' || c == '_' {
+			return unicode.ToLower(c)
+		}
+		return '-'
+	}, href)
+	if id == "" {
+		return "index"
+	}
+	return id
+}
+
+// renderPrint 转换每个文档, 合并为data.MdHtml, 目录为每个文档的目录
+func (md *Markdown) renderPrint(r *http.Request, data *convert.TemplateData, docs []printDoc) error {
+	// 站点内的路径对应的文档: 文档的链接、目录的索引文件, 以及省略 .md 的链接
+	targets := map[string]string{}
+	for _, doc := range docs {
+		targets[doc.Href] = doc.id
+		if strings.HasSuffix(doc.Href, "/") {
+			targets[doc.Href+path.Base(doc.filename)] = doc.id
+			if doc.Href != "/" {
+				targets[strings.TrimSuffix(doc.Href, "/")] = doc.id
+			}
+		} else {
+			targets[strings.TrimSuffix(doc.Href, path.Ext(doc.Href))] = doc.id
+		}
+	}
+	prefix := ""
+	if v := versionFromRequest(r); v != nil {
+		prefix = v.prefix
+	}
+	var html strings.Builder
+	data.Toc = nil
+	for _, doc := range docs {
+		base := doc.Href
+		if !strings.HasSuffix(base, "/") {
+			base = path.Dir(base)
+		}
+		resolve := func(dest string) (string, bool) {
+			u, err := url.Parse(dest)
+			if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+				return "", false
+			}
+			target := u.Path
+			if !strings.HasPrefix(target, "/") {
+				target = path.Join(base, target)
+				if strings.HasSuffix(u.Path, "/") && target != "/" {
+					target += "/"
+				}
+			}
+			if id, ok := targets[target]; ok {
+				if u.Fragment != "" {
+					return "#" + id + ":" + u.Fragment, true
+				}
+				return "#" + id, true
+			}
+			// 其它相对链接(图片、附件等)改为从根目录开始, 在目录的页面中仍然有效
+			if !strings.HasPrefix(u.Path, "/") {
+				u.Path = prefix + target
+				return u.String(), true
+			}
+			return "", false
+		}
+		src, _ := md.expandIncludes(r, doc.filename, doc.content)
+		opts := []convert.ConvertOption{convert.WithShortcodes(md.getShortcodes(r)), convert.WithPrintDoc(doc.id+":", resolve)}
+		if md.Images != nil && md.Images.Srcset {
+			if images := md.imageResolver(r, doc.filename); images != nil {
+				opts = append(opts, convert.WithImages(images))
+			}
+		}
+		page := &convert.TemplateData{}
+		if err := md.engine.Convert(src, page, opts...); err != nil {
+			return err
+		}
+		html.WriteString(`<section class="print-doc" id="` + doc.id + `">` + "\n")
+		html.WriteString(page.MdHtml)
+		html.WriteString("</section>\n")
+
+		item := convert.TocItem{Title: doc.Title, ID: doc.id, Items: page.Toc}
+		// 文档只有一个最高级的标题时, 该标题就是文档的标题
+		if len(page.Toc) == 1 {
+			if convert.MetaString(page.Meta, "Title", "title") == "" {
+				item.Title = page.Toc[0].Title
+			}
+			item.Items = page.Toc[0].Items
+		}
+		data.Toc = append(data.Toc, item)
+	}
+	data.MdHtml = html.String()
+	return nil
+}
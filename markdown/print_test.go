@@ -0,0 +1,72 @@
+package markdown
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPrintID(t *testing.T) {
+	tests := []struct {
+		href, want string
+	}{
+		{"/guide/install.md", "guide-install"},
+		{"/guide/", "guide"},
+		{"/", "index"},
+		{"/README.md", "readme"},
+		{"/v1.2/notes.md", "v1-2-notes"},
+		{"/指南/安装.md", "指南-安装"},
+		{"/a b/c_d.md", "a-b-c_d"},
+	}
+	for _, tt := range tests {
+		if got := printID(tt.href); got != tt.want {
+			t.Errorf("printID(%q) = %q, want %q", tt.href, got, tt.want)
+		}
+	}
+}
+
+func TestServePrint(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":        "# Home\n",
+		"guide/README.md":  "# Guide\n\nsee [install](install.md) and [usage](usage#run)\n",
+		"guide/install.md": "# Install\n\n## Steps\n\n[back](./) [steps](#steps) [top](/guide/README.md)\n",
+		"guide/usage.md":   "# Usage\n\n## Run\n\n![shot](img/shot.png) [site](https://example.com/x.md) [other](/other.md)\n",
+		"other.md":         "# Other\n",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.Print = &PrintView{}
+	})
+	rec := serveTest(t, md, root, newTestRequest(root, "/guide/?print&format=json", ""))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	var data struct {
+		HTML string `json:"html"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"section", `<section class="print-doc" id="guide-install">`},
+		{"prefixed heading id", `id="guide-install:steps"`},
+		{"link to a document", `href="#guide-install"`},
+		{"link without .md", `href="#guide-usage:run"`},
+		{"link to the directory and its index file", `href="#guide"`},
+		{"anchor in the same document", `href="#guide-install:steps"`},
+		{"relative image from the root", `src="/guide/img/shot.png"`},
+		{"external link", `href="https://example.com/x.md"`},
+		{"document outside the directory", `href="/other.md"`},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(data.HTML, tt.want) {
+			t.Errorf("%s: %s not found in\n%s", tt.name, tt.want, data.HTML)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package markdown
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"github.com/kingreatwill/caddy-modules/markdown/git"
+)
+
+// RecentChanges 最近修改的文档. 目录加上 ?recent 返回目录下最近修改的文档(页面、json或markdown),
+// 目录和目录的index文件的 TemplateData.Recent 为该目录下最近修改的文档, 可以用于首页.
+// 修改时间和作者使用git中最后一次提交的, 没有git记录时使用文件的修改时间和元数据中的author.
+type RecentChanges struct {
+	// 列出的文档数, ?recent=<n> 可以指定(最多100). Default: 10
+	Count int `json:"count,omitempty"`
+}
+
+const (
+	defaultRecentCount = 10
+	maxRecentCount     = 100
+)
+
+// recentData ?recent 返回的json
+type recentData struct {
+	Path  string              `json:"path"`
+	Items []convert.RecentDoc `json:"items"`
+	// 索引正在后台更新, 修改时间可能不是最新的
+	Indexing bool `json:"indexing,omitempty"`
+}
+
+// recentCount 列出的文档数, 请求参数 recent=<n> 优先
+func (md *Markdown) recentCount(r *http.Request) int {
+	if n, err := strconv.Atoi(r.URL.Query().Get("recent")); err == nil && n > 0 {
+		return min(n, maxRecentCount)
+	}
+	if md.Recent.Count > 0 {
+		return md.Recent.Count
+	}
+	return defaultRecentCount
+}
+
+// repoPath 文件在仓库中的路径, scope为root在仓库中的路径
+func repoPath(scope, root, filename string) string {
+	if root != "." {
+		filename = strings.TrimPrefix(strings.TrimPrefix(filename, root), "/")
+	}
+	return path.Join(scope, filename)
+}
+
+// recentDocs 按导航树返回目录urlDir下当前用户可以查看的文档中最近修改的count个, 不包含草稿
+func (md *Markdown) recentDocs(r *http.Request, fsys fs.FS, root, urlDir string, count int) ([]convert.RecentDoc, bool) {
+	var idx *git.Index
+	var scope string
+	indexing := false
+	if src, err := md.getActivitySource(fsys, root, root); err == nil {
+		idx, scope = md.gitIndex(src.repo, src.ref, src.head), src.scope
+		indexing = idx == nil || idx.Head != src.head.String()
+	}
+	prefix := ""
+	if v := versionFromRequest(r); v != nil {
+		prefix = v.prefix
+	}
+
+	lang := languageFromRequest(r)
+	var docs []convert.RecentDoc
+	for _, page := range md.treePages(r, md.getSiteTree(fsys, root, lang)) {
+		if !strings.HasPrefix(page.Href, urlDir) {
+			continue
+		}
+		filename, ok := md.pageFile(fsys, root, page.Href, lang)
+		if !ok {
+			continue
+		}
+		info, err := fs.Stat(fsys, filename)
+		if err != nil {
+			continue
+		}
+		meta := md.getDocMeta(fsys, filename, info)
+		if meta.draft || !md.canView(r, page.Href, meta.access) {
+			continue
+		}
+		doc := convert.RecentDoc{Title: page.Title, Href: prefix + page.Href, Summary: meta.summary, Author: meta.author, Modified: info.ModTime()}
+		if idx != nil {
+			if touch, ok := idx.LastTouch(repoPath(scope, root, filename)); ok {
+				doc.Modified, doc.Author, doc.Commit = time.Unix(touch.When, 0), touch.Author, touch.Hash
+			}
+		}
+		docs = append(docs, doc)
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		return docs[i].Modified.After(docs[j].Modified)
+	})
+	if len(docs) > count {
+		docs = docs[:count]
+	}
+	return docs, indexing
+}
+
+// recentMarkdown 最近修改的文档列表, 使用站点模板渲染
+func recentMarkdown(title string, docs []convert.RecentDoc) string {
+	var b strings.Builder
+	b.WriteString("# " + escapeMarkdown(title) + "\n\n")
+	for _, doc := range docs {
+		b.WriteString("- [" + escapeMarkdown(doc.Title) + "](<" + doc.Href + ">) · " + doc.Modified.Format("2006-01-02 15:04"))
+		if doc.Author != "" {
+			b.WriteString(" · " + escapeMarkdown(doc.Author))
+		}
+		b.WriteString("\n")
+		if doc.Summary != "" {
+			b.WriteString("  " + escapeMarkdown(doc.Summary) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// escapeMarkdown 转义文本中的markdown标记
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for _, c := range strings.Join(strings.Fields(s), " ") {
+		if strings.ContainsRune("\\`*_{}[]()<>#+-.!|~:$", c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// serveRecent 返回目录(请求文件时为文件所在的目录)下最近修改的文档.
+// Query: recent[=<n>], format=json 返回json, format=markdown 返回markdown
+func (md *Markdown) serveRecent(w http.ResponseWriter, r *http.Request, format string) error {
+	fsys, err := md.getFS(r)
+	if err != nil {
+		return statusError(err)
+	}
+	root := md.getRoot(r)
+	info, err := fs.Stat(fsys, strings.TrimSuffix(caddyhttp.SanitizedPathJoin(root, r.URL.Path), "/"))
+	if err != nil {
+		return statusError(err)
+	}
+	urlDir := path.Clean("/" + r.URL.Path)
+	if !info.IsDir() {
+		urlDir = path.Dir(urlDir)
+	}
+	urlDir = strings.TrimSuffix(urlDir, "/") + "/"
+	docs, indexing := md.recentDocs(r, fsys, root, urlDir, md.recentCount(r))
+
+	switch format {
+	case formatJSON:
+		if docs == nil {
+			docs = []convert.RecentDoc{}
+		}
+		body, err := json.Marshal(recentData{Path: urlDir, Items: docs, Indexing: indexing})
+		if err != nil {
+			return caddyhttp.Error(http.StatusInternalServerError, err)
+		}
+		return md.writeResponse(w, r, http.StatusOK, body, "application/json; charset=utf-8")
+	case formatMarkdown:
+		return md.writeResponse(w, r, http.StatusOK, []byte(recentMarkdown("Recently updated", docs)), "text/markdown; charset=utf-8")
+	}
+
+	// 目录列表和导航树使用目录的
+	page := r.Clone(r.Context())
+	page.URL.Path = urlDir
+	data, err := md.renderData(page, recentMarkdown("Recently updated", docs))
+	if err != nil {
+		return statusError(err)
+	}
+	data.Title, data.Recent = "Recently updated", docs
+	// 页面不是目录本身的内容
+	data.Canonical, data.MetaTags, data.JSONLD, data.SEOHead = "", nil, "", ""
+	if md.Robots != nil {
+		setNoindex(w)
+	}
+	return md.writeHTML(w, page, http.StatusOK, data, "")
+}
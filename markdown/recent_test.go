@@ -0,0 +1,75 @@
+package markdown
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{"[x](y)", `\[x\]\(y\)`},
+		{"*bold* _it_ `code`", "\\*bold\\* \\_it\\_ \\`code\\`"},
+		{"# title\n\nnext  line", `\# title next line`},
+		{"<b>", `\<b\>`},
+		{"https://a.b", `https\://a\.b`},
+		{"中文", "中文"},
+	}
+	for _, tt := range tests {
+		if got := escapeMarkdown(tt.in); got != tt.want {
+			t.Errorf("escapeMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRecentHidesPrivateDocs(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":      "# Home",
+		"public.md":      "---\nsummary: open\n---\n# Public",
+		"secret.md":      "---\naccess: [team]\nsummary: hidden plans\n---\n# Secret",
+		"draft.md":       "---\ndraft: true\n---\n# Draft",
+		"team/README.md": "---\naccess: [team]\n---\n# Team",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Recent = &RecentChanges{}
+		md.Access = &Access{}
+	})
+
+	tests := []struct {
+		name   string
+		user   string
+		groups []string
+		want   map[string]bool
+	}{
+		{"anonymous", "", nil, map[string]bool{"/": true, "/public.md": true}},
+		{"member", "ann", []string{"team"}, map[string]bool{"/": true, "/public.md": true, "/secret.md": true, "/team/": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveTest(t, md, root, newTestRequest(root, "/?recent&format=json", tt.user, tt.groups...))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status %d", rec.Code)
+			}
+			var data recentData
+			if err := json.Unmarshal(rec.Body.Bytes(), &data); err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]bool{}
+			for _, item := range data.Items {
+				got[item.Href] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("items %v, want %v", got, tt.want)
+			}
+			for href := range tt.want {
+				if !got[href] {
+					t.Errorf("missing %s in %v", href, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package markdown
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+// Robots 生成 /robots.txt, 只包含配置的规则. 隐藏的文件(以.和_开头、hide)、草稿(draft: true)
+// 和需要权限的路径不在robots.txt中列出, 这些页面的响应带有 X-Robots-Tag: noindex.
+type Robots struct {
+	// 不允许抓取的路径
+	Disallow []string `json:"disallow,omitempty"`
+	// 允许抓取的路径
+	Allow []string `json:"allow,omitempty"`
+	// sitemap的地址, 相对地址从site_url开始
+	Sitemaps []string `json:"sitemaps,omitempty"`
+}
+
+const robotsPath = "/robots.txt"
+
+// isDraft 元数据中是否有 draft: true
+func isDraft(metaData map[string]interface{}) bool {
+	return strings.EqualFold(convert.MetaString(metaData, "Draft", "draft"), "true")
+}
+
+func setNoindex(w http.ResponseWriter) {
+	w.Header().Set("X-Robots-Tag", "noindex")
+}
+
+// noindex 路径urlPath是否不希望被搜索引擎收录: 隐藏的文件、hide匹配的文件和需要权限的路径
+func (md *Markdown) noindex(r *http.Request, urlPath string) bool {
+	if md.restricted(urlPath, nil) {
+		return true
+	}
+	segments := strings.FieldsFunc(urlPath, func(c rune) bool { return c == '/' })
+	for _, segment := range segments {
+		if isHiddenName(segment) {
+			return true
+		}
+	}
+	if len(md.Hide) == 0 {
+		return false
+	}
+	repl := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
+	filename, _ := filepath.Abs(filepath.Join(md.getSiteRoot(r), filepath.FromSlash(path.Clean("/"+urlPath))))
+	for _, h := range md.Hide {
+		h = repl.ReplaceAll(h, "")
+		if !strings.ContainsAny(h, `/\`) {
+			// 没有路径分隔符时匹配任意一级的文件名
+			for _, segment := range segments {
+				if ok, _ := path.Match(h, segment); ok {
+					return true
+				}
+			}
+			continue
+		}
+		if abs, err := filepath.Abs(h); err == nil {
+			if ok, _ := filepath.Match(abs, filename); ok || strings.HasPrefix(filename, abs+string(filepath.Separator)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serveRobots 返回robots.txt, 只包含配置的规则, 不列出隐藏、草稿和需要权限的路径
+func (md *Markdown) serveRobots(w http.ResponseWriter, r *http.Request) error {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	for _, p := range md.Robots.Allow {
+		fmt.Fprintf(&b, "Allow: %s\n", p)
+	}
+	for _, p := range md.Robots.Disallow {
+		fmt.Fprintf(&b, "Disallow: %s\n", p)
+	}
+	if len(md.Robots.Sitemaps) > 0 {
+		b.WriteString("\n")
+		site := md.siteURL(r)
+		for _, sitemap := range md.Robots.Sitemaps {
+			if !strings.Contains(sitemap, "://") {
+				// 没有配置site_url时无法生成绝对地址
+				if site == "" {
+					continue
+				}
+				sitemap = site + "/" + strings.TrimPrefix(sitemap, "/")
+			}
+			fmt.Fprintf(&b, "Sitemap: %s\n", sitemap)
+		}
+	}
+	return md.writeResponse(w, r, http.StatusOK, []byte(b.String()), "text/plain; charset=utf-8")
+}
@@ -0,0 +1,39 @@
+package markdown
+
+import "testing"
+
+func TestRobots(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":         "# Home\n",
+		"draft.md":          "---\ndraft: true\n---\n# Draft\n",
+		"secret/README.md":  "---\naccess: [team]\n---\n# Secret\n",
+		"_private/notes.md": "# Notes\n",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.Robots = &Robots{Disallow: []string{"/tmp/"}, Sitemaps: []string{"/sitemap.xml"}}
+		md.SiteURL = "https://example.com"
+	})
+
+	rec := serveTest(t, md, root, newTestRequest(root, "/robots.txt", ""))
+	want := "User-agent: *\nDisallow: /tmp/\n\nSitemap: https://example.com/sitemap.xml\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("robots.txt = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		target  string
+		noindex bool
+	}{
+		{"/", false},
+		{"/draft.md", true},
+		{"/_private/notes.md", true},
+	}
+	for _, tt := range tests {
+		rec := serveTest(t, md, root, newTestRequest(root, tt.target, ""))
+		if got := rec.Header().Get("X-Robots-Tag") == "noindex"; got != tt.noindex {
+			t.Errorf("%s: noindex = %v, want %v (status %d)", tt.target, got, tt.noindex, rec.Code)
+		}
+	}
+}
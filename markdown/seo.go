@@ -0,0 +1,208 @@
+package markdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+// 元数据中date支持的格式
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// siteURL 站点地址: 配置的site_url(支持占位符), 没有配置时为空.
+// 不使用请求的Host, 它由客户端决定, 不能用于规范链接
+func (md *Markdown) siteURL(r *http.Request) string {
+	if md.SiteURL == "" {
+		return ""
+	}
+	repl := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
+	return strings.TrimSuffix(repl.ReplaceAll(md.SiteURL, ""), "/")
+}
+
+// setSEO 设置规范连接、预览图片、Open Graph和Twitter Card的<meta>以及Article和BreadcrumbList的JSON-LD.
+// 没有配置site_url时不生成规范链接、og:url和JSON-LD中的链接, 预览图片为站点内的路径
+func (md *Markdown) setSEO(r *http.Request, data *convert.TemplateData) {
+	data.SiteUrl = md.siteURL(r)
+	original := r.Context().Value(caddyhttp.OriginalRequestCtxKey).(http.Request)
+	pageURL, err := url.Parse(data.SiteUrl + (&url.URL{Path: original.URL.Path}).EscapedPath())
+	if err != nil {
+		return
+	}
+	if data.SiteUrl != "" {
+		data.Canonical = pageURL.String()
+	}
+	if data.Image != "" {
+		// 相对地址从当前文档开始
+		if image, err := pageURL.Parse(data.Image); err == nil {
+			data.Image = image.String()
+		}
+	}
+
+	isArticle := data.CurrentIsFile && strings.Trim(r.URL.Path, "/") != ""
+	published := metaDate(data.Meta, "Date", "date", "Published", "published")
+	var modified string
+	if fsys, err := md.getFS(r); err == nil && data.CurrentIsFile {
+		if info, err := fs.Stat(fsys, data.CurrentFile); err == nil {
+			modified = info.ModTime().UTC().Format(time.RFC3339)
+		}
+	}
+	var keywords []string
+	for _, k := range strings.Split(data.Keywords, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+
+	var tags []convert.MetaTag
+	add := func(attr, key, content string) {
+		if content != "" {
+			tags = append(tags, convert.MetaTag{Attr: attr, Key: key, Content: content})
+		}
+	}
+	if isArticle {
+		add("property", "og:type", "article")
+	} else {
+		add("property", "og:type", "website")
+	}
+	add("property", "og:title", data.Title)
+	add("property", "og:description", data.Description)
+	add("property", "og:url", data.Canonical)
+	add("property", "og:image", data.Image)
+	add("property", "og:locale", strings.ReplaceAll(data.Lang, "-", "_"))
+	for _, l := range data.Languages {
+		if !l.Current {
+			add("property", "og:locale:alternate", strings.ReplaceAll(l.Lang, "-", "_"))
+		}
+	}
+	if isArticle {
+		add("property", "article:published_time", published)
+		add("property", "article:modified_time", modified)
+		for _, k := range keywords {
+			add("property", "article:tag", k)
+		}
+	}
+	if data.Image != "" {
+		add("name", "twitter:card", "summary_large_image")
+	} else {
+		add("name", "twitter:card", "summary")
+	}
+	add("name", "twitter:title", data.Title)
+	add("name", "twitter:description", data.Description)
+	add("name", "twitter:image", data.Image)
+	data.MetaTags = tags
+
+	var graph []interface{}
+	if isArticle {
+		article := map[string]interface{}{
+			"@type":    "Article",
+			"headline": data.Title,
+		}
+		setIf := func(key string, value interface{}) {
+			if s, ok := value.(string); !ok || s != "" {
+				article[key] = value
+			}
+		}
+		setIf("mainEntityOfPage", data.Canonical)
+		setIf("description", data.Description)
+		setIf("image", data.Image)
+		setIf("datePublished", published)
+		setIf("dateModified", modified)
+		setIf("inLanguage", data.Lang)
+		if len(keywords) > 0 {
+			article["keywords"] = keywords
+		}
+		if author := convert.MetaString(data.Meta, "Author", "author"); author != "" {
+			article["author"] = map[string]string{"@type": "Person", "name": author}
+		}
+		if data.WordCount > 0 {
+			article["wordCount"] = data.WordCount
+		}
+		graph = append(graph, article)
+	}
+	// BreadcrumbList 的每一项都需要绝对地址
+	if data.SiteUrl != "" {
+		if crumbs := md.breadcrumbs(r, data); len(crumbs) > 1 {
+			graph = append(graph, map[string]interface{}{"@type": "BreadcrumbList", "itemListElement": crumbs})
+		}
+	}
+	if len(graph) > 0 {
+		// json.Marshal 会转义 < > &, 可以直接放在<script>中
+		if ld, err := json.Marshal(map[string]interface{}{"@context": "https://schema.org", "@graph": graph}); err == nil {
+			data.JSONLD = string(ld)
+		}
+	}
+	data.SEOHead = seoHead(data)
+}
+
+// breadcrumbs 从首页到当前页面的每一级目录, 最后一项为当前页面
+func (md *Markdown) breadcrumbs(r *http.Request, data *convert.TemplateData) []map[string]interface{} {
+	base := data.SiteUrl
+	if v := versionFromRequest(r); v != nil {
+		base += v.prefix
+	}
+	crumb := func(position int, name, p string) map[string]interface{} {
+		return map[string]interface{}{
+			"@type":    "ListItem",
+			"position": position,
+			"name":     name,
+			"item":     base + (&url.URL{Path: p}).EscapedPath(),
+		}
+	}
+	crumbs := []map[string]interface{}{crumb(1, "Home", "/")}
+	segments := strings.FieldsFunc(r.URL.Path, func(c rune) bool { return c == '/' })
+	for i, segment := range segments {
+		p := "/" + path.Join(segments[:i+1]...)
+		name := segment
+		if i == len(segments)-1 {
+			name = data.Title
+		}
+		if i < len(segments)-1 || strings.HasSuffix(r.URL.Path, "/") {
+			p += "/"
+		}
+		crumbs = append(crumbs, crumb(i+2, name, p))
+	}
+	return crumbs
+}
+
+// seoHead 生成可以直接放在<head>中的html
+func seoHead(data *convert.TemplateData) string {
+	var b strings.Builder
+	if data.Canonical != "" {
+		fmt.Fprintf(&b, "<link rel=\"canonical\" href=\"%s\">\n", html.EscapeString(data.Canonical))
+	}
+	for _, tag := range data.MetaTags {
+		fmt.Fprintf(&b, "<meta %s=\"%s\" content=\"%s\">\n", tag.Attr, html.EscapeString(tag.Key), html.EscapeString(tag.Content))
+	}
+	if data.JSONLD != "" {
+		fmt.Fprintf(&b, "<script type=\"application/ld+json\">%s</script>\n", data.JSONLD)
+	}
+	return b.String()
+}
+
+// metaDate 元数据中的日期转换为RFC3339格式, 无法解析时原样返回
+func metaDate(metaData map[string]interface{}, keys ...string) string {
+	value, ok := convert.MetaValue(metaData, keys...)
+	if !ok || value == nil {
+		return ""
+	}
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	s := strings.TrimSpace(fmt.Sprintf("%v", value))
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+func TestSetSEO(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"docs/a.md": "# A\n"})
+
+	tests := []struct {
+		name      string
+		siteURL   string
+		canonical string
+		image     string
+		crumbs    bool
+	}{
+		{"site url", "https://docs.example.com/", "https://docs.example.com/docs/a.md", "https://docs.example.com/docs/cover.png", true},
+		// 不使用客户端发送的Host
+		{"no site url", "", "", "/docs/cover.png", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newTestMarkdown(t, func(md *Markdown) {
+				md.Root = root
+				md.SiteURL = tt.siteURL
+			})
+			r := newTestRequest(root, "/docs/a.md", "")
+			r.Host = "evil.example"
+			data := &convert.TemplateData{Title: "A", Image: "cover.png", CurrentIsFile: true, CurrentFile: "docs/a.md"}
+			md.setSEO(r, data)
+
+			if data.Canonical != tt.canonical {
+				t.Errorf("Canonical = %q, want %q", data.Canonical, tt.canonical)
+			}
+			if data.Image != tt.image {
+				t.Errorf("Image = %q, want %q", data.Image, tt.image)
+			}
+			var ogURL string
+			for _, tag := range data.MetaTags {
+				if tag.Key == "og:url" {
+					ogURL = tag.Content
+				}
+			}
+			if ogURL != tt.canonical {
+				t.Errorf("og:url = %q, want %q", ogURL, tt.canonical)
+			}
+			if got := strings.Contains(data.JSONLD, "BreadcrumbList"); got != tt.crumbs {
+				t.Errorf("BreadcrumbList in JSON-LD = %v, want %v", got, tt.crumbs)
+			}
+			if strings.Contains(data.SEOHead, "evil.example") {
+				t.Errorf("SEO head uses the request host: %s", data.SEOHead)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package markdown
 
 import (
 	"mime"
+	"strconv"
 
 	"github.com/caddyserver/caddy/v2"
 	"github.com/caddyserver/caddy/v2/caddyconfig/httpcaddyfile"
 	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/dustin/go-humanize"
 )
 
 func init() {
@@ -25,6 +27,64 @@ func init() {
 //
 //     markdown [<matcher>] {
 //         template <name>
+//         root <path>
+//         hide <files...>
+//         index <filenames...>
+//         page_size <n>
+//         fs <filesystem>
+//         languages <default> <others...>
+//         git_index_dir <path|off>
+//         shortcodes <dir>
+//         tree [<depth>]
+//         csv [<max_rows>]
+//         print [<max_docs>]
+//         recent [<count>]
+//         site_url <url>
+//         heading_ids <unicode|pinyin|ascii>
+//         permalinks [<symbol>]
+//         reading_speed <words_per_minute> [<cjk_chars_per_minute>]
+//         versions {
+//             repository <path>
+//             dir <dir>
+//             prefix <prefix>
+//             pattern <glob>
+//             branches <names...>
+//             default <version>
+//         }
+//         sync {
+//             repository <path>
+//             remote <name>
+//             branch <name>
+//             interval <duration>
+//             webhook <path> <secret>
+//         }
+//         images {
+//             cache_dir <path>
+//             widths <widths...>
+//             quality <1-100>
+//             format <webp|jpeg|png>
+//             srcset
+//             cache_max_age <duration>
+//         }
+//         access {
+//             rule <path> [<users|groups...>]
+//             groups <placeholder>
+//             deny_status <403|404>
+//         }
+//         robots {
+//             disallow <paths...>
+//             allow <paths...>
+//             sitemap <url>
+//         }
+//         cache {
+//             max_size <size>
+//             ttl <duration>
+//             encodings <br|zstd|gzip...>
+//         }
+//         stream_threshold <size|off>
+//         error_pages {
+//             <status|*> <filename>
+//         }
 //     }
 //
 func parseCaddyfile(h httpcaddyfile.Helper) (caddyhttp.MiddlewareHandler, error) {
@@ -46,6 +106,345 @@ func parseCaddyfile(h httpcaddyfile.Helper) (caddyhttp.MiddlewareHandler, error)
 				if len(md.Hide) == 0 {
 					return nil, h.ArgErr()
 				}
+			case "page_size":
+				var size string
+				if !h.Args(&size) {
+					return nil, h.ArgErr()
+				}
+				pageSize, err := strconv.Atoi(size)
+				if err != nil {
+					return nil, h.Errf("bad page_size value '%s': %v", size, err)
+				}
+				md.PageSize = pageSize
+			case "fs":
+				if !h.Args(&md.FileSystem) {
+					return nil, h.ArgErr()
+				}
+			case "versions":
+				md.Versions = new(Versions)
+				for h.NextBlock(1) {
+					switch h.Val() {
+					case "repository":
+						if !h.Args(&md.Versions.Repository) {
+							return nil, h.ArgErr()
+						}
+					case "dir":
+						if !h.Args(&md.Versions.Dir) {
+							return nil, h.ArgErr()
+						}
+					case "prefix":
+						if !h.Args(&md.Versions.Prefix) {
+							return nil, h.ArgErr()
+						}
+					case "pattern":
+						if !h.Args(&md.Versions.Pattern) {
+							return nil, h.ArgErr()
+						}
+					case "branches":
+						md.Versions.Branches = h.RemainingArgs()
+					case "default":
+						if !h.Args(&md.Versions.Default) {
+							return nil, h.ArgErr()
+						}
+					default:
+						return nil, h.Errf("unrecognized versions subdirective '%s'", h.Val())
+					}
+				}
+			case "languages":
+				md.Languages = h.RemainingArgs()
+				if len(md.Languages) == 0 {
+					return nil, h.ArgErr()
+				}
+			case "heading_ids":
+				if !h.Args(&md.HeadingIDs) {
+					return nil, h.ArgErr()
+				}
+			case "permalinks":
+				md.Permalinks = "#"
+				switch args := h.RemainingArgs(); len(args) {
+				case 0:
+				case 1:
+					md.Permalinks = args[0]
+				default:
+					return nil, h.ArgErr()
+				}
+			case "reading_speed":
+				args := h.RemainingArgs()
+				if len(args) == 0 || len(args) > 2 {
+					return nil, h.ArgErr()
+				}
+				speeds := []*int{&md.WordsPerMinute, &md.CJKCharsPerMinute}
+				for i, arg := range args {
+					speed, err := strconv.Atoi(arg)
+					if err != nil || speed <= 0 {
+						return nil, h.Errf("invalid reading speed '%s'", arg)
+					}
+					*speeds[i] = speed
+				}
+			case "robots":
+				md.Robots = new(Robots)
+				for h.NextBlock(1) {
+					switch h.Val() {
+					case "disallow":
+						args := h.RemainingArgs()
+						if len(args) == 0 {
+							return nil, h.ArgErr()
+						}
+						md.Robots.Disallow = append(md.Robots.Disallow, args...)
+					case "allow":
+						args := h.RemainingArgs()
+						if len(args) == 0 {
+							return nil, h.ArgErr()
+						}
+						md.Robots.Allow = append(md.Robots.Allow, args...)
+					case "sitemap":
+						var sitemap string
+						if !h.Args(&sitemap) {
+							return nil, h.ArgErr()
+						}
+						md.Robots.Sitemaps = append(md.Robots.Sitemaps, sitemap)
+					default:
+						return nil, h.Errf("unrecognized robots subdirective '%s'", h.Val())
+					}
+				}
+			case "cache":
+				md.Cache = new(RenderCache)
+				for h.NextBlock(1) {
+					switch h.Val() {
+					case "max_size":
+						var size string
+						if !h.Args(&size) {
+							return nil, h.ArgErr()
+						}
+						n, err := humanize.ParseBytes(size)
+						if err != nil || n == 0 {
+							return nil, h.Errf("bad max_size value '%s'", size)
+						}
+						md.Cache.MaxSize = int64(n)
+					case "ttl":
+						var ttl string
+						if !h.Args(&ttl) {
+							return nil, h.ArgErr()
+						}
+						dur, err := caddy.ParseDuration(ttl)
+						if err != nil {
+							return nil, h.Errf("bad ttl value '%s': %v", ttl, err)
+						}
+						md.Cache.TTL = caddy.Duration(dur)
+					case "encodings":
+						md.Cache.Encodings = h.RemainingArgs()
+						if len(md.Cache.Encodings) == 0 {
+							return nil, h.ArgErr()
+						}
+					default:
+						return nil, h.Errf("unrecognized cache subdirective '%s'", h.Val())
+					}
+				}
+			case "stream_threshold":
+				var size string
+				if !h.Args(&size) {
+					return nil, h.ArgErr()
+				}
+				if size == "off" {
+					md.StreamThreshold = -1
+					continue
+				}
+				n, err := humanize.ParseBytes(size)
+				if err != nil || n == 0 {
+					return nil, h.Errf("bad stream_threshold value '%s'", size)
+				}
+				md.StreamThreshold = int64(n)
+			case "site_url":
+				if !h.Args(&md.SiteURL) {
+					return nil, h.ArgErr()
+				}
+			case "tree":
+				md.Tree = new(NavTree)
+				switch args := h.RemainingArgs(); len(args) {
+				case 0:
+				case 1:
+					depth, err := strconv.Atoi(args[0])
+					if err != nil || depth < 0 {
+						return nil, h.Errf("invalid tree depth '%s'", args[0])
+					}
+					md.Tree.Depth = depth
+				default:
+					return nil, h.ArgErr()
+				}
+			case "csv":
+				md.CSV = new(CSVTables)
+				switch args := h.RemainingArgs(); len(args) {
+				case 0:
+				case 1:
+					rows, err := strconv.Atoi(args[0])
+					if err != nil || rows <= 0 {
+						return nil, h.Errf("invalid csv max_rows '%s'", args[0])
+					}
+					md.CSV.MaxRows = rows
+				default:
+					return nil, h.ArgErr()
+				}
+			case "print":
+				md.Print = new(PrintView)
+				switch args := h.RemainingArgs(); len(args) {
+				case 0:
+				case 1:
+					docs, err := strconv.Atoi(args[0])
+					if err != nil || docs <= 0 {
+						return nil, h.Errf("invalid print max_docs '%s'", args[0])
+					}
+					md.Print.MaxDocs = docs
+				default:
+					return nil, h.ArgErr()
+				}
+			case "recent":
+				md.Recent = new(RecentChanges)
+				switch args := h.RemainingArgs(); len(args) {
+				case 0:
+				case 1:
+					count, err := strconv.Atoi(args[0])
+					if err != nil || count <= 0 {
+						return nil, h.Errf("invalid recent count '%s'", args[0])
+					}
+					md.Recent.Count = count
+				default:
+					return nil, h.ArgErr()
+				}
+			case "shortcodes":
+				if !h.Args(&md.Shortcodes) {
+					return nil, h.ArgErr()
+				}
+			case "git_index_dir":
+				if !h.Args(&md.GitIndexDir) {
+					return nil, h.ArgErr()
+				}
+			case "sync":
+				md.Sync = new(Sync)
+				for h.NextBlock(1) {
+					switch h.Val() {
+					case "repository":
+						if !h.Args(&md.Sync.Repository) {
+							return nil, h.ArgErr()
+						}
+					case "remote":
+						if !h.Args(&md.Sync.Remote) {
+							return nil, h.ArgErr()
+						}
+					case "branch":
+						if !h.Args(&md.Sync.Branch) {
+							return nil, h.ArgErr()
+						}
+					case "interval":
+						var interval string
+						if !h.Args(&interval) {
+							return nil, h.ArgErr()
+						}
+						dur, err := caddy.ParseDuration(interval)
+						if err != nil {
+							return nil, h.Errf("bad interval value '%s': %v", interval, err)
+						}
+						md.Sync.Interval = caddy.Duration(dur)
+					case "webhook":
+						if !h.Args(&md.Sync.WebhookPath, &md.Sync.Secret) {
+							return nil, h.ArgErr()
+						}
+					default:
+						return nil, h.Errf("unrecognized sync subdirective '%s'", h.Val())
+					}
+				}
+			case "images":
+				md.Images = new(Images)
+				for h.NextBlock(1) {
+					switch h.Val() {
+					case "cache_dir":
+						if !h.Args(&md.Images.CacheDir) {
+							return nil, h.ArgErr()
+						}
+					case "widths":
+						args := h.RemainingArgs()
+						if len(args) == 0 {
+							return nil, h.ArgErr()
+						}
+						for _, arg := range args {
+							width, err := strconv.Atoi(arg)
+							if err != nil || width <= 0 {
+								return nil, h.Errf("bad widths value '%s'", arg)
+							}
+							md.Images.Widths = append(md.Images.Widths, width)
+						}
+					case "quality":
+						var quality string
+						if !h.Args(&quality) {
+							return nil, h.ArgErr()
+						}
+						q, err := strconv.Atoi(quality)
+						if err != nil {
+							return nil, h.Errf("bad quality value '%s': %v", quality, err)
+						}
+						md.Images.Quality = q
+					case "format":
+						if !h.Args(&md.Images.Format) {
+							return nil, h.ArgErr()
+						}
+					case "srcset":
+						md.Images.Srcset = true
+					case "cache_max_age":
+						var age string
+						if !h.Args(&age) {
+							return nil, h.ArgErr()
+						}
+						dur, err := caddy.ParseDuration(age)
+						if err != nil || dur <= 0 {
+							return nil, h.Errf("bad cache_max_age value '%s'", age)
+						}
+						md.Images.CacheMaxAge = caddy.Duration(dur)
+					default:
+						return nil, h.Errf("unrecognized images subdirective '%s'", h.Val())
+					}
+				}
+			case "access":
+				md.Access = new(Access)
+				for h.NextBlock(1) {
+					switch h.Val() {
+					case "rule":
+						var rule AccessRule
+						if !h.NextArg() {
+							return nil, h.ArgErr()
+						}
+						rule.Path = h.Val()
+						rule.Allow = h.RemainingArgs()
+						md.Access.Rules = append(md.Access.Rules, rule)
+					case "groups":
+						if !h.Args(&md.Access.Groups) {
+							return nil, h.ArgErr()
+						}
+					case "deny_status":
+						var status string
+						if !h.Args(&status) {
+							return nil, h.ArgErr()
+						}
+						code, err := strconv.Atoi(status)
+						if err != nil {
+							return nil, h.Errf("bad deny_status value '%s': %v", status, err)
+						}
+						md.Access.DenyStatus = code
+					default:
+						return nil, h.Errf("unrecognized access subdirective '%s'", h.Val())
+					}
+				}
+			case "error_pages":
+				md.ErrorPages = make(map[string]string)
+				for h.NextBlock(1) {
+					status := h.Val()
+					var name string
+					if !h.Args(&name) {
+						return nil, h.ArgErr()
+					}
+					md.ErrorPages[status] = name
+				}
+				if err := validErrorPages(md.ErrorPages); err != nil {
+					return nil, h.Err(err.Error())
+				}
 			case "index":
 				md.IndexNames = h.RemainingArgs()
 				if len(md.IndexNames) == 0 {
@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"io/fs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"github.com/kingreatwill/caddy-modules/markdown/template"
+	"go.uber.org/zap"
+)
+
+// shortcodeSet 目录中的短代码模板, 文件变化后重新解析
+type shortcodeSet struct {
+	sig        string
+	shortcodes convert.Shortcodes
+}
+
+// shortcodeDir 短代码目录: 相对于模板文件所在的目录, 使用内置模板或者以/开头时相对于root
+func (md *Markdown) shortcodeDir(r *http.Request) string {
+	dir := md.Shortcodes
+	if _, builtin := template.Templates[md.Template]; !builtin && md.Template != "" && !strings.HasPrefix(dir, "/") {
+		dir = path.Join(path.Dir(md.Template), dir)
+	}
+	return strings.TrimSuffix(caddyhttp.SanitizedPathJoin(md.getSiteRoot(r), dir), "/")
+}
+
+// getShortcodes 返回内置的短代码以及 shortcodes 目录中的模板(<name>.html), 目录中的模板优先.
+// <name>.paired.html 为需要结束标记的短代码
+func (md *Markdown) getShortcodes(r *http.Request) convert.Shortcodes {
+	if md.Shortcodes == "" {
+		return convert.DefaultShortcodes
+	}
+	fsys, err := md.getSiteFS(r)
+	if err != nil {
+		return convert.DefaultShortcodes
+	}
+	dir := md.shortcodeDir(r)
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		md.logger.Debug("shortcodes", zap.String("dir", dir), zap.Error(err))
+		return convert.DefaultShortcodes
+	}
+
+	// 按文件名、修改时间和大小判断目录是否变化
+	var sig strings.Builder
+	var files []fs.FileInfo
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".html" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		sig.WriteString(info.Name() + "\x00" + strconv.FormatInt(info.ModTime().UnixNano(), 36) + "\x00" + strconv.FormatInt(info.Size(), 36) + "\n")
+	}
+	key := fileKey{fsys: fsys, name: dir}
+	if v, ok := md.shortcodeCache.Load(key); ok && v.(*shortcodeSet).sig == sig.String() {
+		return v.(*shortcodeSet).shortcodes
+	}
+
+	shortcodes := make(convert.Shortcodes, len(convert.DefaultShortcodes)+len(files))
+	for name, sc := range convert.DefaultShortcodes {
+		shortcodes[name] = sc
+	}
+	for _, info := range files {
+		src, err := fs.ReadFile(fsys, path.Join(dir, info.Name()))
+		if err != nil {
+			md.logger.Error("shortcode error:", zap.String("file", info.Name()), zap.Error(err))
+			continue
+		}
+		name := strings.TrimSuffix(info.Name(), ".html")
+		name, paired := strings.CutSuffix(name, ".paired")
+		sc, err := convert.ParseShortcode(name, string(src), paired)
+		if err != nil {
+			md.logger.Error("shortcode error:", zap.String("file", info.Name()), zap.Error(err))
+			continue
+		}
+		shortcodes[name] = sc
+	}
+	md.shortcodeCache.Store(key, &shortcodeSet{sig: sig.String(), shortcodes: shortcodes})
+	return shortcodes
+}
@@ -0,0 +1,23 @@
+package markdown
+
+import "testing"
+
+func TestShortcodeDir(t *testing.T) {
+	tests := []struct {
+		template   string
+		shortcodes string
+		want       string
+	}{
+		{"", "_shortcodes", "site/_shortcodes"},
+		{"simple", "_shortcodes", "site/_shortcodes"},
+		{"theme/page.tmpl", "_shortcodes", "site/theme/_shortcodes"},
+		{"theme/page.tmpl", "/_shortcodes", "site/_shortcodes"},
+		{"theme/page.tmpl", "../../etc", "site/etc"},
+	}
+	for _, tt := range tests {
+		md := &Markdown{Root: "site", Template: tt.template, Shortcodes: tt.shortcodes}
+		if got := md.shortcodeDir(newTestRequest("site", "/", "")); got != tt.want {
+			t.Errorf("shortcodeDir(%q, %q) = %q, want %q", tt.template, tt.shortcodes, got, tt.want)
+		}
+	}
+}
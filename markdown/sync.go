@@ -0,0 +1,201 @@
+package markdown
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/git"
+	"go.uber.org/zap"
+)
+
+// Sync 定时或者由webhook触发从远程仓库拉取并快进根目录所在的仓库,
+// 同步后清空缓存(元数据、git统计等).
+type Sync struct {
+	// 仓库路径, 默认使用root(包含占位符时使用第一个请求的root)
+	Repository string `json:"repository,omitempty"`
+	// 远程仓库名. Default: origin
+	Remote string `json:"remote,omitempty"`
+	// 需要快进的分支, 默认当前分支
+	Branch string `json:"branch,omitempty"`
+	// 定时同步的间隔, 为0时只通过webhook同步
+	Interval caddy.Duration `json:"interval,omitempty"`
+	// webhook的请求路径, 例如 /_sync
+	WebhookPath string `json:"webhook_path,omitempty"`
+	// webhook签名(HMAC)的密钥, 支持 GitHub/Gitea/Gogs, 支持全局占位符(例如 {env.SYNC_SECRET})
+	Secret string `json:"secret,omitempty"`
+
+	repository atomic.Value // 实际同步的仓库路径
+	trigger    chan struct{}
+	logger     *zap.Logger
+}
+
+// webhook请求体的最大长度
+const webhookMaxBytes = 5 << 20
+
+// provisionSync 启动同步的后台任务, caddy重新加载配置时退出
+func (md *Markdown) provisionSync(ctx caddy.Context) error {
+	s := md.Sync
+	if s == nil {
+		return nil
+	}
+	if s.WebhookPath != "" && s.Secret == "" {
+		return fmt.Errorf("sync: webhook_path requires a secret")
+	}
+	s.Secret = caddy.NewReplacer().ReplaceAll(s.Secret, "")
+	s.logger = md.logger.Named("sync")
+	s.trigger = make(chan struct{}, 1)
+	repo := caddy.NewReplacer().ReplaceAll(s.Repository, "")
+	if repo == "" && !strings.Contains(md.Root, "{") {
+		repo = md.Root
+	}
+	if repo != "" {
+		s.repository.Store(repo)
+	}
+
+	go func() {
+		var tick <-chan time.Time
+		if s.Interval > 0 {
+			ticker := time.NewTicker(time.Duration(s.Interval))
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+			case <-s.trigger:
+			}
+			md.syncNow()
+		}
+	}()
+	return nil
+}
+
+// rememberSyncRoot root包含占位符时, 使用请求中的root作为同步的仓库
+func (md *Markdown) rememberSyncRoot(r *http.Request) {
+	if md.Sync == nil || md.Sync.repository.Load() != nil {
+		return
+	}
+	md.Sync.repository.CompareAndSwap(nil, md.getSiteRoot(r))
+}
+
+// syncNow 拉取远程仓库, 有更新时清空缓存
+func (md *Markdown) syncNow() {
+	s := md.Sync
+	repo, _ := s.repository.Load().(string)
+	if repo == "" {
+		s.logger.Debug("sync skipped, repository is unknown until the first request")
+		return
+	}
+	start := time.Now()
+	updated, err := git.Pull(repo, git.PullOptions{Remote: s.Remote, Branch: s.Branch})
+	if err != nil {
+		s.logger.Error("sync failed", zap.String("repository", repo), zap.Error(err))
+	}
+	if updated {
+		md.invalidate()
+	}
+	s.logger.Info("sync finished",
+		zap.String("repository", repo),
+		zap.Bool("updated", updated),
+		zap.Duration("duration", time.Since(start)))
+}
+
+// triggerSync 触发一次同步, 已经有等待中的同步时忽略
+func (md *Markdown) triggerSync() {
+	select {
+	case md.Sync.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// invalidate 清空所有根据文件内容生成的缓存
+func (md *Markdown) invalidate() {
+	clearCache(&md.gitMap)
+	clearCache(&md.metaCache)
+	clearCache(&md.orderCache)
+	clearCache(&md.treeCache)
+	clearCache(&md.shortcodeCache)
+	clearCache(&md.imageSizes)
+	clearCache(&md.versionCache)
+	clearCache(&md.restrictedCache)
+	// 重新打开仓库, 以便读取到新的packfile; 索引从保存的文件或者新打开的仓库重新加载
+	clearCache(&md.repoCache)
+	clearCache(&md.indexes)
+	if md.renderCache != nil {
+		md.renderCache.clear()
+	}
+}
+
+func clearCache(m *sync.Map) {
+	m.Range(func(k, _ any) bool {
+		m.Delete(k)
+		return true
+	})
+}
+
+// isWebhook 是否同步的webhook请求
+func (md *Markdown) isWebhook(r *http.Request) bool {
+	return md.Sync != nil && md.Sync.WebhookPath != "" && r.URL.Path == md.Sync.WebhookPath
+}
+
+// serveWebhook 校验签名后触发同步
+func (md *Markdown) serveWebhook(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		return caddyhttp.Error(http.StatusMethodNotAllowed, nil)
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, webhookMaxBytes))
+	if err != nil {
+		return caddyhttp.Error(http.StatusBadRequest, err)
+	}
+	if !verifySignature(r.Header, body, md.Sync.Secret) {
+		return caddyhttp.Error(http.StatusUnauthorized, fmt.Errorf("invalid webhook signature"))
+	}
+	if r.Header.Get("X-GitHub-Event") == "ping" {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	md.triggerSync()
+	w.WriteHeader(http.StatusAccepted)
+	return nil
+}
+
+// verifySignature 校验 GitHub(X-Hub-Signature-256/X-Hub-Signature)
+// 和 Gitea/Gogs(X-Gitea-Signature/X-Gogs-Signature) 的HMAC签名
+func verifySignature(header http.Header, body []byte, secret string) bool {
+	check := func(newHash func() hash.Hash, signature string) bool {
+		expected, err := hex.DecodeString(signature)
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(newHash, []byte(secret))
+		mac.Write(body)
+		return hmac.Equal(mac.Sum(nil), expected)
+	}
+	if sig := header.Get("X-Hub-Signature-256"); sig != "" {
+		return strings.HasPrefix(sig, "sha256=") && check(sha256.New, strings.TrimPrefix(sig, "sha256="))
+	}
+	if sig := header.Get("X-Hub-Signature"); sig != "" {
+		return strings.HasPrefix(sig, "sha1=") && check(sha1.New, strings.TrimPrefix(sig, "sha1="))
+	}
+	for _, name := range []string{"X-Gitea-Signature", "X-Gogs-Signature"} {
+		if sig := header.Get(name); sig != "" {
+			return check(sha256.New, sig)
+		}
+	}
+	return false
+}
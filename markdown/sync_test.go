@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestVerifySignature(t *testing.T) {
+	const secret = "s3cret"
+	body := []byte(`{"ref":"refs/heads/main"}`)
+	sign := func(newHash func() hash.Hash, key string) string {
+		mac := hmac.New(newHash, []byte(key))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   bool
+	}{
+		{"github sha256", "X-Hub-Signature-256", "sha256=" + sign(sha256.New, secret), true},
+		{"github sha1", "X-Hub-Signature", "sha1=" + sign(sha1.New, secret), true},
+		{"gitea", "X-Gitea-Signature", sign(sha256.New, secret), true},
+		{"gogs", "X-Gogs-Signature", sign(sha256.New, secret), true},
+		{"wrong secret", "X-Hub-Signature-256", "sha256=" + sign(sha256.New, "other"), false},
+		{"missing prefix", "X-Hub-Signature-256", sign(sha256.New, secret), false},
+		{"wrong algorithm", "X-Hub-Signature", "sha1=" + sign(sha256.New, secret), false},
+		{"not hex", "X-Gitea-Signature", "zz", false},
+		{"no signature", "X-Other", sign(sha256.New, secret), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(tt.header, tt.value)
+			if got := verifySignature(header, body, secret); got != tt.want {
+				t.Errorf("verifySignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	md := &Markdown{}
+	caches := []struct {
+		name string
+		m    *sync.Map
+	}{
+		{"gitMap", &md.gitMap},
+		{"metaCache", &md.metaCache},
+		{"repoCache", &md.repoCache},
+		{"indexes", &md.indexes},
+		{"shortcodeCache", &md.shortcodeCache},
+		{"imageSizes", &md.imageSizes},
+		{"orderCache", &md.orderCache},
+		{"treeCache", &md.treeCache},
+		{"versionCache", &md.versionCache},
+		{"restrictedCache", &md.restrictedCache},
+	}
+	for _, c := range caches {
+		c.m.Store("key", "value")
+	}
+	md.invalidate()
+	for _, c := range caches {
+		if _, ok := c.m.Load("key"); ok {
+			t.Errorf("%s is not cleared", c.name)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+//go:build ignore
+
+// gen_icons 由 static/js/icons.js 生成 icons_gen.go, 图标文件不存在时报错.
+// 在 markdown/template 目录中执行: go generate
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+const (
+	iconsJS  = "../../static/js/icons.js"
+	iconsDir = "../../static/icons"
+	output   = "icons_gen.go"
+)
+
+// icons.js 中的 var file_icon = {...} 和 var folder_icon = {...}
+var iconVar = regexp.MustCompile(`(?m)^var (file_icon|folder_icon) = (\{.*\})`)
+
+func main() {
+	src, err := os.ReadFile(iconsJS)
+	if err != nil {
+		log.Fatal(err)
+	}
+	maps := map[string]map[string]string{}
+	for _, m := range iconVar.FindAllSubmatch(src, -1) {
+		var icons map[string]string
+		if err := json.Unmarshal(m[2], &icons); err != nil {
+			log.Fatalf("%s: %s: %v", iconsJS, m[1], err)
+		}
+		maps[string(m[1])] = icons
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_icons.go from static/js/icons.js; DO NOT EDIT.\n\npackage template\n")
+	for _, v := range []struct{ js, name, doc string }{
+		{"file_icon", "fileIcons", "a file extension"},
+		{"folder_icon", "folderIcons", "a folder name"},
+	} {
+		icons, ok := maps[v.js]
+		if !ok {
+			log.Fatalf("%s: %s not found", iconsJS, v.js)
+		}
+		keys := make([]string, 0, len(icons))
+		for key, icon := range icons {
+			if _, err := os.Stat(filepath.Join(iconsDir, icon)); err != nil {
+				log.Fatalf("%s: %s[%q]: %v", iconsJS, v.js, key, err)
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(&b, "\n// %s maps %s to an icon under /static/icons.\nvar %s = map[string]string{\n", v.name, v.doc, v.name)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "\t%q: %q,\n", key, icons[key])
+		}
+		b.WriteString("}\n")
+	}
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(output, out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,23 @@
+package template
+
+import "strings"
+
+//go:generate go run gen_icons.go
+
+const iconsPath = "/static/icons/"
+
+// GetExtensionsIcon 返回文件后缀名(目录则为目录名)对应的图标地址,
+// 与 static/js/icons.js 中的 getFileIconPath/getFolderIconPath 保持一致
+func GetExtensionsIcon(ext string, isDir bool) string {
+	icons, def := fileIcons, "default_file.svg"
+	if isDir {
+		icons, def = folderIcons, "default_folder.svg"
+	}
+	if src, ok := icons[ext]; ok && src != "" {
+		return iconsPath + src
+	}
+	if src, ok := icons[strings.ToLower(ext)]; ok && src != "" {
+		return iconsPath + src
+	}
+	return iconsPath + def
+}
@@ -0,0 +1,1930 @@
+// Code generated by gen_icons.go from static/js/icons.js; DO NOT EDIT.
+
+package template
+
+// fileIcons maps a file extension to an icon under /static/icons.
+var fileIcons = map[string]string{
+	"":                         "default_file.svg",
+	".7z":                      "file_type_zip.svg",
+	".access":                  "file_type_access.svg",
+	".access2":                 "file_type_access2.svg",
+	".actionscript":            "file_type_actionscript.svg",
+	".actionscript2":           "file_type_actionscript2.svg",
+	".ada":                     "file_type_ada.svg",
+	".advpl":                   "file_type_advpl.svg",
+	".affectscript":            "file_type_affectscript.svg",
+	".affinitydesigner":        "file_type_affinitydesigner.svg",
+	".affinityphoto":           "file_type_affinityphoto.svg",
+	".affinitypublisher":       "file_type_affinitypublisher.svg",
+	".ai":                      "file_type_ai.svg",
+	".ai2":                     "file_type_ai2.svg",
+	".al":                      "file_type_al.svg",
+	".angular":                 "file_type_angular.svg",
+	".ansible":                 "file_type_ansible.svg",
+	".antlr":                   "file_type_antlr.svg",
+	".anyscript":               "file_type_anyscript.svg",
+	".apache":                  "file_type_apache.svg",
+	".apex":                    "file_type_apex.svg",
+	".api_extractor":           "file_type_api_extractor.svg",
+	".apib":                    "file_type_apib.svg",
+	".apib2":                   "file_type_apib2.svg",
+	".apl":                     "file_type_apl.svg",
+	".applescript":             "file_type_applescript.svg",
+	".appsemble":               "file_type_appsemble.svg",
+	".appveyor":                "file_type_appveyor.svg",
+	".arduino":                 "file_type_arduino.svg",
+	".asciidoc":                "file_type_asciidoc.svg",
+	".asp":                     "file_type_asp.svg",
+	".aspx":                    "file_type_aspx.svg",
+	".assembly":                "file_type_assembly.svg",
+	".astro":                   "file_type_astro.svg",
+	".astroconfig":             "file_type_astroconfig.svg",
+	".ats":                     "file_type_ats.svg",
+	".audio":                   "file_type_audio.svg",
+	".aurelia":                 "file_type_aurelia.svg",
+	".autohotkey":              "file_type_autohotkey.svg",
+	".autoit":                  "file_type_autoit.svg",
+	".avif":                    "file_type_avif.svg",
+	".avro":                    "file_type_avro.svg",
+	".awk":                     "file_type_awk.svg",
+	".aws":                     "file_type_aws.svg",
+	".azure":                   "file_type_azure.svg",
+	".azurepipelines":          "file_type_azurepipelines.svg",
+	".babel":                   "file_type_babel.svg",
+	".babel2":                  "file_type_babel2.svg",
+	".ballerina":               "file_type_ballerina.svg",
+	".bat":                     "file_type_bat.svg",
+	".bats":                    "file_type_bats.svg",
+	".bazaar":                  "file_type_bazaar.svg",
+	".bazel":                   "file_type_bazel.svg",
+	".befunge":                 "file_type_befunge.svg",
+	".bicep":                   "file_type_bicep.svg",
+	".biml":                    "file_type_biml.svg",
+	".binary":                  "file_type_binary.svg",
+	".bitbucketpipeline":       "file_type_bitbucketpipeline.svg",
+	".bithound":                "file_type_bithound.svg",
+	".blade":                   "file_type_blade.svg",
+	".blitzbasic":              "file_type_blitzbasic.svg",
+	".bmp":                     "file_type_image.svg",
+	".bolt":                    "file_type_bolt.svg",
+	".bosque":                  "file_type_bosque.svg",
+	".bower":                   "file_type_bower.svg",
+	".bower2":                  "file_type_bower2.svg",
+	".browserslist":            "file_type_browserslist.svg",
+	".buckbuild":               "file_type_buckbuild.svg",
+	".bundler":                 "file_type_bundler.svg",
+	".bz":                      "file_type_zip.svg",
+	".c":                       "file_type_c.svg",
+	".c2":                      "file_type_c2.svg",
+	".c3":                      "file_type_c3.svg",
+	".c_al":                    "file_type_c_al.svg",
+	".cabal":                   "file_type_cabal.svg",
+	".caddy":                   "file_type_caddy.svg",
+	".cake":                    "file_type_cake.svg",
+	".cakephp":                 "file_type_cakephp.svg",
+	".capacitor":               "file_type_capacitor.svg",
+	".cargo":                   "file_type_cargo.svg",
+	".casc":                    "file_type_casc.svg",
+	".cddl":                    "file_type_cddl.svg",
+	".cert":                    "file_type_cert.svg",
+	".ceylon":                  "file_type_ceylon.svg",
+	".cf":                      "file_type_cf.svg",
+	".cf2":                     "file_type_cf2.svg",
+	".cfc":                     "file_type_cfc.svg",
+	".cfc2":                    "file_type_cfc2.svg",
+	".cfm":                     "file_type_cfm.svg",
+	".cfm2":                    "file_type_cfm2.svg",
+	".cheader":                 "file_type_cheader.svg",
+	".chef":                    "file_type_chef.svg",
+	".chef_cookbook":           "file_type_chef_cookbook.svg",
+	".circleci":                "file_type_circleci.svg",
+	".class":                   "file_type_class.svg",
+	".clojure":                 "file_type_clojure.svg",
+	".clojurescript":           "file_type_clojurescript.svg",
+	".cloudfoundry":            "file_type_cloudfoundry.svg",
+	".cmake":                   "file_type_cmake.svg",
+	".cobol":                   "file_type_cobol.svg",
+	".codacy":                  "file_type_codacy.svg",
+	".codeclimate":             "file_type_codeclimate.svg",
+	".codecov":                 "file_type_codecov.svg",
+	".codekit":                 "file_type_codekit.svg",
+	".codeql":                  "file_type_codeql.svg",
+	".coffeelint":              "file_type_coffeelint.svg",
+	".coffeescript":            "file_type_coffeescript.svg",
+	".commitlint":              "file_type_commitlint.svg",
+	".compass":                 "file_type_compass.svg",
+	".composer":                "file_type_composer.svg",
+	".conan":                   "file_type_conan.svg",
+	".conda":                   "file_type_conda.svg",
+	".config":                  "file_type_config.svg",
+	".confluence":              "file_type_confluence.svg",
+	".coveralls":               "file_type_coveralls.svg",
+	".cpp":                     "file_type_cpp.svg",
+	".cpp2":                    "file_type_cpp2.svg",
+	".cpp3":                    "file_type_cpp3.svg",
+	".cppheader":               "file_type_cppheader.svg",
+	".crowdin":                 "file_type_crowdin.svg",
+	".crystal":                 "file_type_crystal.svg",
+	".csharp":                  "file_type_csharp.svg",
+	".csharp2":                 "file_type_csharp2.svg",
+	".csproj":                  "file_type_csproj.svg",
+	".css":                     "file_type_css.svg",
+	".csscomb":                 "file_type_csscomb.svg",
+	".csslint":                 "file_type_csslint.svg",
+	".cssmap":                  "file_type_cssmap.svg",
+	".cucumber":                "file_type_cucumber.svg",
+	".cuda":                    "file_type_cuda.svg",
+	".cvs":                     "file_type_cvs.svg",
+	".cypress":                 "file_type_cypress.svg",
+	".cython":                  "file_type_cython.svg",
+	".dal":                     "file_type_dal.svg",
+	".darcs":                   "file_type_darcs.svg",
+	".dartlang":                "file_type_dartlang.svg",
+	".db":                      "file_type_db.svg",
+	".delphi":                  "file_type_delphi.svg",
+	".dependabot":              "file_type_dependabot.svg",
+	".dependencies":            "file_type_dependencies.svg",
+	".devcontainer":            "file_type_devcontainer.svg",
+	".diff":                    "file_type_diff.svg",
+	".django":                  "file_type_django.svg",
+	".dlang":                   "file_type_dlang.svg",
+	".docker":                  "file_type_docker.svg",
+	".docker2":                 "file_type_docker2.svg",
+	".dockertest":              "file_type_dockertest.svg",
+	".dockertest2":             "file_type_dockertest2.svg",
+	".docpad":                  "file_type_docpad.svg",
+	".docz":                    "file_type_docz.svg",
+	".dojo":                    "file_type_dojo.svg",
+	".dotjs":                   "file_type_dotjs.svg",
+	".doxygen":                 "file_type_doxygen.svg",
+	".drawio":                  "file_type_drawio.svg",
+	".drone":                   "file_type_drone.svg",
+	".drools":                  "file_type_drools.svg",
+	".dustjs":                  "file_type_dustjs.svg",
+	".dvc":                     "file_type_dvc.svg",
+	".dylan":                   "file_type_dylan.svg",
+	".edge":                    "file_type_edge.svg",
+	".edge2":                   "file_type_edge2.svg",
+	".editorconfig":            "file_type_editorconfig.svg",
+	".eex":                     "file_type_eex.svg",
+	".ejs":                     "file_type_ejs.svg",
+	".elastic":                 "file_type_elastic.svg",
+	".elasticbeanstalk":        "file_type_elasticbeanstalk.svg",
+	".elixir":                  "file_type_elixir.svg",
+	".elm":                     "file_type_elm.svg",
+	".elm2":                    "file_type_elm2.svg",
+	".emacs":                   "file_type_emacs.svg",
+	".ember":                   "file_type_ember.svg",
+	".ensime":                  "file_type_ensime.svg",
+	".eps":                     "file_type_eps.svg",
+	".erb":                     "file_type_erb.svg",
+	".erlang":                  "file_type_erlang.svg",
+	".erlang2":                 "file_type_erlang2.svg",
+	".eslint":                  "file_type_eslint.svg",
+	".eslint2":                 "file_type_eslint2.svg",
+	".excel":                   "file_type_excel.svg",
+	".excel2":                  "file_type_excel2.svg",
+	".expo":                    "file_type_expo.svg",
+	".falcon":                  "file_type_falcon.svg",
+	".fauna":                   "file_type_fauna.svg",
+	".favicon":                 "file_type_favicon.svg",
+	".fbx":                     "file_type_fbx.svg",
+	".firebase":                "file_type_firebase.svg",
+	".firebasehosting":         "file_type_firebasehosting.svg",
+	".firestore":               "file_type_firestore.svg",
+	".fla":                     "file_type_fla.svg",
+	".flash":                   "file_type_flash.svg",
+	".floobits":                "file_type_floobits.svg",
+	".flow":                    "file_type_flow.svg",
+	".flutter":                 "file_type_flutter.svg",
+	".flutter_package":         "file_type_flutter_package.svg",
+	".font":                    "file_type_font.svg",
+	".fortran":                 "file_type_fortran.svg",
+	".fossa":                   "file_type_fossa.svg",
+	".fossil":                  "file_type_fossil.svg",
+	".freemarker":              "file_type_freemarker.svg",
+	".fsharp":                  "file_type_fsharp.svg",
+	".fsharp2":                 "file_type_fsharp2.svg",
+	".fsproj":                  "file_type_fsproj.svg",
+	".fthtml":                  "file_type_fthtml.svg",
+	".fusebox":                 "file_type_fusebox.svg",
+	".galen":                   "file_type_galen.svg",
+	".galen2":                  "file_type_galen2.svg",
+	".gamemaker":               "file_type_gamemaker.svg",
+	".gamemaker2":              "file_type_gamemaker2.svg",
+	".gamemaker81":             "file_type_gamemaker81.svg",
+	".gatsby":                  "file_type_gatsby.svg",
+	".gcode":                   "file_type_gcode.svg",
+	".genstat":                 "file_type_genstat.svg",
+	".gif":                     "file_type_image.svg",
+	".git":                     "file_type_git.svg",
+	".git2":                    "file_type_git2.svg",
+	".gitlab":                  "file_type_gitlab.svg",
+	".gitpod":                  "file_type_gitpod.svg",
+	".glide":                   "file_type_glide.svg",
+	".glitter":                 "file_type_glitter.svg",
+	".glsl":                    "file_type_glsl.svg",
+	".glyphs":                  "file_type_glyphs.svg",
+	".gnuplot":                 "file_type_gnuplot.svg",
+	".go":                      "file_type_go.svg",
+	".go_aqua":                 "file_type_go_aqua.svg",
+	".go_black":                "file_type_go_black.svg",
+	".go_fuchsia":              "file_type_go_fuchsia.svg",
+	".go_gopher":               "file_type_go_gopher.svg",
+	".go_lightblue":            "file_type_go_lightblue.svg",
+	".go_package":              "file_type_go_package.svg",
+	".go_white":                "file_type_go_white.svg",
+	".go_yellow":               "file_type_go_yellow.svg",
+	".godot":                   "file_type_godot.svg",
+	".gradle":                  "file_type_gradle.svg",
+	".gradle2":                 "file_type_gradle2.svg",
+	".graphql":                 "file_type_graphql.svg",
+	".graphql_config":          "file_type_graphql_config.svg",
+	".graphviz":                "file_type_graphviz.svg",
+	".greenkeeper":             "file_type_greenkeeper.svg",
+	".gridsome":                "file_type_gridsome.svg",
+	".groovy":                  "file_type_groovy.svg",
+	".groovy2":                 "file_type_groovy2.svg",
+	".grunt":                   "file_type_grunt.svg",
+	".gulp":                    "file_type_gulp.svg",
+	".gz":                      "file_type_zip.svg",
+	".haml":                    "file_type_haml.svg",
+	".handlebars":              "file_type_handlebars.svg",
+	".handlebars2":             "file_type_handlebars2.svg",
+	".harbour":                 "file_type_harbour.svg",
+	".haskell":                 "file_type_haskell.svg",
+	".haskell2":                "file_type_haskell2.svg",
+	".haxe":                    "file_type_haxe.svg",
+	".haxecheckstyle":          "file_type_haxecheckstyle.svg",
+	".haxedevelop":             "file_type_haxedevelop.svg",
+	".helix":                   "file_type_helix.svg",
+	".helm":                    "file_type_helm.svg",
+	".hjson":                   "file_type_hjson.svg",
+	".hlsl":                    "file_type_hlsl.svg",
+	".homeassistant":           "file_type_homeassistant.svg",
+	".horusec":                 "file_type_horusec.svg",
+	".host":                    "file_type_host.svg",
+	".html":                    "file_type_html.svg",
+	".htmlhint":                "file_type_htmlhint.svg",
+	".http":                    "file_type_http.svg",
+	".hunspell":                "file_type_hunspell.svg",
+	".husky":                   "file_type_husky.svg",
+	".hy":                      "file_type_hy.svg",
+	".hygen":                   "file_type_hygen.svg",
+	".hypr":                    "file_type_hypr.svg",
+	".icl":                     "file_type_icl.svg",
+	".ico":                     "file_type_image.svg",
+	".idris":                   "file_type_idris.svg",
+	".idrisbin":                "file_type_idrisbin.svg",
+	".idrispkg":                "file_type_idrispkg.svg",
+	".image":                   "file_type_image.svg",
+	".imba":                    "file_type_imba.svg",
+	".inc":                     "file_type_inc.svg",
+	".infopath":                "file_type_infopath.svg",
+	".informix":                "file_type_informix.svg",
+	".ini":                     "file_type_ini.svg",
+	".ink":                     "file_type_ink.svg",
+	".innosetup":               "file_type_innosetup.svg",
+	".io":                      "file_type_io.svg",
+	".iodine":                  "file_type_iodine.svg",
+	".ionic":                   "file_type_ionic.svg",
+	".jake":                    "file_type_jake.svg",
+	".janet":                   "file_type_janet.svg",
+	".jar":                     "file_type_jar.svg",
+	".jasmine":                 "file_type_jasmine.svg",
+	".java":                    "file_type_java.svg",
+	".jbuilder":                "file_type_jbuilder.svg",
+	".jekyll":                  "file_type_jekyll.svg",
+	".jenkins":                 "file_type_jenkins.svg",
+	".jest":                    "file_type_jest.svg",
+	".jest_snapshot":           "file_type_jest_snapshot.svg",
+	".jinja":                   "file_type_jinja.svg",
+	".jpeg":                    "file_type_image.svg",
+	".jpg":                     "file_type_image.svg",
+	".jpm":                     "file_type_jpm.svg",
+	".js":                      "file_type_js.svg",
+	".js_official":             "file_type_js_official.svg",
+	".jsbeautify":              "file_type_jsbeautify.svg",
+	".jsconfig":                "file_type_jsconfig.svg",
+	".jscpd":                   "file_type_jscpd.svg",
+	".jshint":                  "file_type_jshint.svg",
+	".jsmap":                   "file_type_jsmap.svg",
+	".json":                    "file_type_json.svg",
+	".json2":                   "file_type_json2.svg",
+	".json5":                   "file_type_json5.svg",
+	".json_official":           "file_type_json_official.svg",
+	".jsonld":                  "file_type_jsonld.svg",
+	".jsonnet":                 "file_type_jsonnet.svg",
+	".jsp":                     "file_type_jsp.svg",
+	".jss":                     "file_type_jss.svg",
+	".julia":                   "file_type_julia.svg",
+	".julia2":                  "file_type_julia2.svg",
+	".jupyter":                 "file_type_jupyter.svg",
+	".karma":                   "file_type_karma.svg",
+	".key":                     "file_type_key.svg",
+	".kitchenci":               "file_type_kitchenci.svg",
+	".kite":                    "file_type_kite.svg",
+	".kivy":                    "file_type_kivy.svg",
+	".kos":                     "file_type_kos.svg",
+	".kotlin":                  "file_type_kotlin.svg",
+	".kusto":                   "file_type_kusto.svg",
+	".latino":                  "file_type_latino.svg",
+	".layout":                  "file_type_layout.svg",
+	".lerna":                   "file_type_lerna.svg",
+	".less":                    "file_type_less.svg",
+	".lex":                     "file_type_lex.svg",
+	".license":                 "file_type_license.svg",
+	".licensebat":              "file_type_licensebat.svg",
+	".light_actionscript2":     "file_type_light_actionscript2.svg",
+	".light_ada":               "file_type_light_ada.svg",
+	".light_apl":               "file_type_light_apl.svg",
+	".light_babel":             "file_type_light_babel.svg",
+	".light_babel2":            "file_type_light_babel2.svg",
+	".light_cabal":             "file_type_light_cabal.svg",
+	".light_circleci":          "file_type_light_circleci.svg",
+	".light_cloudfoundry":      "file_type_light_cloudfoundry.svg",
+	".light_codacy":            "file_type_light_codacy.svg",
+	".light_codeclimate":       "file_type_light_codeclimate.svg",
+	".light_config":            "file_type_light_config.svg",
+	".light_crystal":           "file_type_light_crystal.svg",
+	".light_db":                "file_type_light_db.svg",
+	".light_docpad":            "file_type_light_docpad.svg",
+	".light_drone":             "file_type_light_drone.svg",
+	".light_expo":              "file_type_light_expo.svg",
+	".light_firebasehosting":   "file_type_light_firebasehosting.svg",
+	".light_fla":               "file_type_light_fla.svg",
+	".light_font":              "file_type_light_font.svg",
+	".light_gamemaker2":        "file_type_light_gamemaker2.svg",
+	".light_gradle":            "file_type_light_gradle.svg",
+	".light_hjson":             "file_type_light_hjson.svg",
+	".light_ini":               "file_type_light_ini.svg",
+	".light_io":                "file_type_light_io.svg",
+	".light_js":                "file_type_light_js.svg",
+	".light_jsconfig":          "file_type_light_jsconfig.svg",
+	".light_jsmap":             "file_type_light_jsmap.svg",
+	".light_json":              "file_type_light_json.svg",
+	".light_json5":             "file_type_light_json5.svg",
+	".light_jsonld":            "file_type_light_jsonld.svg",
+	".light_kite":              "file_type_light_kite.svg",
+	".light_lerna":             "file_type_light_lerna.svg",
+	".light_mdx":               "file_type_light_mdx.svg",
+	".light_mlang":             "file_type_light_mlang.svg",
+	".light_mustache":          "file_type_light_mustache.svg",
+	".light_next":              "file_type_light_next.svg",
+	".light_nim":               "file_type_light_nim.svg",
+	".light_openHAB":           "file_type_light_openHAB.svg",
+	".light_pcl":               "file_type_light_pcl.svg",
+	".light_pnpm":              "file_type_light_pnpm.svg",
+	".light_prettier":          "file_type_light_prettier.svg",
+	".light_prisma":            "file_type_light_prisma.svg",
+	".light_purescript":        "file_type_light_purescript.svg",
+	".light_razzle":            "file_type_light_razzle.svg",
+	".light_rehype":            "file_type_light_rehype.svg",
+	".light_remark":            "file_type_light_remark.svg",
+	".light_retext":            "file_type_light_retext.svg",
+	".light_rubocop":           "file_type_light_rubocop.svg",
+	".light_rust":              "file_type_light_rust.svg",
+	".light_rust_toolchain":    "file_type_light_rust_toolchain.svg",
+	".light_shaderlab":         "file_type_light_shaderlab.svg",
+	".light_solidity":          "file_type_light_solidity.svg",
+	".light_stylelint":         "file_type_light_stylelint.svg",
+	".light_stylus":            "file_type_light_stylus.svg",
+	".light_symfony":           "file_type_light_symfony.svg",
+	".light_systemd":           "file_type_light_systemd.svg",
+	".light_systemverilog":     "file_type_light_systemverilog.svg",
+	".light_testcafe":          "file_type_light_testcafe.svg",
+	".light_testjs":            "file_type_light_testjs.svg",
+	".light_tex":               "file_type_light_tex.svg",
+	".light_todo":              "file_type_light_todo.svg",
+	".light_toml":              "file_type_light_toml.svg",
+	".light_unibeautify":       "file_type_light_unibeautify.svg",
+	".light_vash":              "file_type_light_vash.svg",
+	".light_vsix":              "file_type_light_vsix.svg",
+	".light_vsixmanifest":      "file_type_light_vsixmanifest.svg",
+	".light_xfl":               "file_type_light_xfl.svg",
+	".light_yaml":              "file_type_light_yaml.svg",
+	".light_zeit":              "file_type_light_zeit.svg",
+	".lighthouse":              "file_type_lighthouse.svg",
+	".lime":                    "file_type_lime.svg",
+	".lintstagedrc":            "file_type_lintstagedrc.svg",
+	".liquid":                  "file_type_liquid.svg",
+	".lisp":                    "file_type_lisp.svg",
+	".livescript":              "file_type_livescript.svg",
+	".lnk":                     "file_type_lnk.svg",
+	".locale":                  "file_type_locale.svg",
+	".log":                     "file_type_log.svg",
+	".lolcode":                 "file_type_lolcode.svg",
+	".lsl":                     "file_type_lsl.svg",
+	".lua":                     "file_type_lua.svg",
+	".lync":                    "file_type_lync.svg",
+	".makefile":                "file_type_makefile.svg",
+	".manifest":                "file_type_manifest.svg",
+	".manifest_bak":            "file_type_manifest_bak.svg",
+	".manifest_skip":           "file_type_manifest_skip.svg",
+	".map":                     "file_type_map.svg",
+	".mariadb":                 "file_type_mariadb.svg",
+	".markdown":                "file_type_markdown.svg",
+	".markdownlint":            "file_type_markdownlint.svg",
+	".marko":                   "file_type_marko.svg",
+	".markojs":                 "file_type_markojs.svg",
+	".matlab":                  "file_type_matlab.svg",
+	".maven":                   "file_type_maven.svg",
+	".maxscript":               "file_type_maxscript.svg",
+	".maya":                    "file_type_maya.svg",
+	".md":                      "file_type_markdown.svg",
+	".mdx":                     "file_type_mdx.svg",
+	".mediawiki":               "file_type_mediawiki.svg",
+	".mercurial":               "file_type_mercurial.svg",
+	".meson":                   "file_type_meson.svg",
+	".meteor":                  "file_type_meteor.svg",
+	".mjml":                    "file_type_mjml.svg",
+	".mlang":                   "file_type_mlang.svg",
+	".mocha":                   "file_type_mocha.svg",
+	".mod":                     "file_type_go_package.svg",
+	".modernizr":               "file_type_modernizr.svg",
+	".mojolicious":             "file_type_mojolicious.svg",
+	".moleculer":               "file_type_moleculer.svg",
+	".mongo":                   "file_type_mongo.svg",
+	".monotone":                "file_type_monotone.svg",
+	".mson":                    "file_type_mson.svg",
+	".mustache":                "file_type_mustache.svg",
+	".mysql":                   "file_type_mysql.svg",
+	".nearly":                  "file_type_nearly.svg",
+	".nest_adapter_js":         "file_type_nest_adapter_js.svg",
+	".nest_adapter_ts":         "file_type_nest_adapter_ts.svg",
+	".nest_controller_js":      "file_type_nest_controller_js.svg",
+	".nest_controller_ts":      "file_type_nest_controller_ts.svg",
+	".nest_decorator_js":       "file_type_nest_decorator_js.svg",
+	".nest_decorator_ts":       "file_type_nest_decorator_ts.svg",
+	".nest_filter_js":          "file_type_nest_filter_js.svg",
+	".nest_filter_ts":          "file_type_nest_filter_ts.svg",
+	".nest_gateway_js":         "file_type_nest_gateway_js.svg",
+	".nest_gateway_ts":         "file_type_nest_gateway_ts.svg",
+	".nest_guard_js":           "file_type_nest_guard_js.svg",
+	".nest_guard_ts":           "file_type_nest_guard_ts.svg",
+	".nest_interceptor_js":     "file_type_nest_interceptor_js.svg",
+	".nest_interceptor_ts":     "file_type_nest_interceptor_ts.svg",
+	".nest_middleware_js":      "file_type_nest_middleware_js.svg",
+	".nest_middleware_ts":      "file_type_nest_middleware_ts.svg",
+	".nest_module_js":          "file_type_nest_module_js.svg",
+	".nest_module_ts":          "file_type_nest_module_ts.svg",
+	".nest_pipe_js":            "file_type_nest_pipe_js.svg",
+	".nest_pipe_ts":            "file_type_nest_pipe_ts.svg",
+	".nest_service_js":         "file_type_nest_service_js.svg",
+	".nest_service_ts":         "file_type_nest_service_ts.svg",
+	".nestjs":                  "file_type_nestjs.svg",
+	".netlify":                 "file_type_netlify.svg",
+	".next":                    "file_type_next.svg",
+	".ng_component_css":        "file_type_ng_component_css.svg",
+	".ng_component_dart":       "file_type_ng_component_dart.svg",
+	".ng_component_html":       "file_type_ng_component_html.svg",
+	".ng_component_js":         "file_type_ng_component_js.svg",
+	".ng_component_js2":        "file_type_ng_component_js2.svg",
+	".ng_component_less":       "file_type_ng_component_less.svg",
+	".ng_component_sass":       "file_type_ng_component_sass.svg",
+	".ng_component_scss":       "file_type_ng_component_scss.svg",
+	".ng_component_ts":         "file_type_ng_component_ts.svg",
+	".ng_component_ts2":        "file_type_ng_component_ts2.svg",
+	".ng_controller_js":        "file_type_ng_controller_js.svg",
+	".ng_controller_ts":        "file_type_ng_controller_ts.svg",
+	".ng_directive_dart":       "file_type_ng_directive_dart.svg",
+	".ng_directive_js":         "file_type_ng_directive_js.svg",
+	".ng_directive_js2":        "file_type_ng_directive_js2.svg",
+	".ng_directive_ts":         "file_type_ng_directive_ts.svg",
+	".ng_directive_ts2":        "file_type_ng_directive_ts2.svg",
+	".ng_guard_dart":           "file_type_ng_guard_dart.svg",
+	".ng_guard_js":             "file_type_ng_guard_js.svg",
+	".ng_guard_ts":             "file_type_ng_guard_ts.svg",
+	".ng_interceptor_dart":     "file_type_ng_interceptor_dart.svg",
+	".ng_interceptor_js":       "file_type_ng_interceptor_js.svg",
+	".ng_interceptor_ts":       "file_type_ng_interceptor_ts.svg",
+	".ng_module_dart":          "file_type_ng_module_dart.svg",
+	".ng_module_js":            "file_type_ng_module_js.svg",
+	".ng_module_js2":           "file_type_ng_module_js2.svg",
+	".ng_module_ts":            "file_type_ng_module_ts.svg",
+	".ng_module_ts2":           "file_type_ng_module_ts2.svg",
+	".ng_pipe_dart":            "file_type_ng_pipe_dart.svg",
+	".ng_pipe_js":              "file_type_ng_pipe_js.svg",
+	".ng_pipe_js2":             "file_type_ng_pipe_js2.svg",
+	".ng_pipe_ts":              "file_type_ng_pipe_ts.svg",
+	".ng_pipe_ts2":             "file_type_ng_pipe_ts2.svg",
+	".ng_routing_dart":         "file_type_ng_routing_dart.svg",
+	".ng_routing_js":           "file_type_ng_routing_js.svg",
+	".ng_routing_js2":          "file_type_ng_routing_js2.svg",
+	".ng_routing_ts":           "file_type_ng_routing_ts.svg",
+	".ng_routing_ts2":          "file_type_ng_routing_ts2.svg",
+	".ng_service_dart":         "file_type_ng_service_dart.svg",
+	".ng_service_js":           "file_type_ng_service_js.svg",
+	".ng_service_js2":          "file_type_ng_service_js2.svg",
+	".ng_service_ts":           "file_type_ng_service_ts.svg",
+	".ng_service_ts2":          "file_type_ng_service_ts2.svg",
+	".ng_smart_component_dart": "file_type_ng_smart_component_dart.svg",
+	".ng_smart_component_js":   "file_type_ng_smart_component_js.svg",
+	".ng_smart_component_js2":  "file_type_ng_smart_component_js2.svg",
+	".ng_smart_component_ts":   "file_type_ng_smart_component_ts.svg",
+	".ng_smart_component_ts2":  "file_type_ng_smart_component_ts2.svg",
+	".ng_tailwind":             "file_type_ng_tailwind.svg",
+	".nginx":                   "file_type_nginx.svg",
+	".nim":                     "file_type_nim.svg",
+	".nimble":                  "file_type_nimble.svg",
+	".ninja":                   "file_type_ninja.svg",
+	".nix":                     "file_type_nix.svg",
+	".njsproj":                 "file_type_njsproj.svg",
+	".node":                    "file_type_node.svg",
+	".node2":                   "file_type_node2.svg",
+	".nodemon":                 "file_type_nodemon.svg",
+	".npm":                     "file_type_npm.svg",
+	".nsi":                     "file_type_nsi.svg",
+	".nsri":                    "file_type_nsri.svg",
+	".nsri-integrity":          "file_type_nsri-integrity.svg",
+	".nuget":                   "file_type_nuget.svg",
+	".numpy":                   "file_type_numpy.svg",
+	".nunjucks":                "file_type_nunjucks.svg",
+	".nuxt":                    "file_type_nuxt.svg",
+	".nyc":                     "file_type_nyc.svg",
+	".objectivec":              "file_type_objectivec.svg",
+	".objectivecpp":            "file_type_objectivecpp.svg",
+	".ocaml":                   "file_type_ocaml.svg",
+	".ogone":                   "file_type_ogone.svg",
+	".onenote":                 "file_type_onenote.svg",
+	".openHAB":                 "file_type_openHAB.svg",
+	".opencl":                  "file_type_opencl.svg",
+	".openscad":                "file_type_openscad.svg",
+	".org":                     "file_type_org.svg",
+	".outlook":                 "file_type_outlook.svg",
+	".ovpn":                    "file_type_ovpn.svg",
+	".package":                 "file_type_package.svg",
+	".paket":                   "file_type_paket.svg",
+	".patch":                   "file_type_patch.svg",
+	".pcl":                     "file_type_pcl.svg",
+	".pddl":                    "file_type_pddl.svg",
+	".pddl_happenings":         "file_type_pddl_happenings.svg",
+	".pddl_plan":               "file_type_pddl_plan.svg",
+	".pdf":                     "file_type_pdf.svg",
+	".pdf2":                    "file_type_pdf2.svg",
+	".perl":                    "file_type_perl.svg",
+	".perl2":                   "file_type_perl2.svg",
+	".perl6":                   "file_type_perl6.svg",
+	".pgsql":                   "file_type_pgsql.svg",
+	".photoshop":               "file_type_photoshop.svg",
+	".photoshop2":              "file_type_photoshop2.svg",
+	".php":                     "file_type_php.svg",
+	".php2":                    "file_type_php2.svg",
+	".php3":                    "file_type_php3.svg",
+	".phpcsfixer":              "file_type_phpcsfixer.svg",
+	".phpunit":                 "file_type_phpunit.svg",
+	".phraseapp":               "file_type_phraseapp.svg",
+	".pine":                    "file_type_pine.svg",
+	".pip":                     "file_type_pip.svg",
+	".pipeline":                "file_type_pipeline.svg",
+	".plantuml":                "file_type_plantuml.svg",
+	".platformio":              "file_type_platformio.svg",
+	".plsql":                   "file_type_plsql.svg",
+	".plsql_package":           "file_type_plsql_package.svg",
+	".plsql_package_body":      "file_type_plsql_package_body.svg",
+	".plsql_package_header":    "file_type_plsql_package_header.svg",
+	".plsql_package_spec":      "file_type_plsql_package_spec.svg",
+	".png":                     "file_type_image.svg",
+	".pnpm":                    "file_type_pnpm.svg",
+	".poedit":                  "file_type_poedit.svg",
+	".polymer":                 "file_type_polymer.svg",
+	".pony":                    "file_type_pony.svg",
+	".postcss":                 "file_type_postcss.svg",
+	".postcssconfig":           "file_type_postcssconfig.svg",
+	".powerpoint":              "file_type_powerpoint.svg",
+	".powerpoint2":             "file_type_powerpoint2.svg",
+	".powershell":              "file_type_powershell.svg",
+	".powershell2":             "file_type_powershell2.svg",
+	".powershell_format":       "file_type_powershell_format.svg",
+	".powershell_psd":          "file_type_powershell_psd.svg",
+	".powershell_psd2":         "file_type_powershell_psd2.svg",
+	".powershell_psm":          "file_type_powershell_psm.svg",
+	".powershell_psm2":         "file_type_powershell_psm2.svg",
+	".powershell_types":        "file_type_powershell_types.svg",
+	".preact":                  "file_type_preact.svg",
+	".precommit":               "file_type_precommit.svg",
+	".prettier":                "file_type_prettier.svg",
+	".prisma":                  "file_type_prisma.svg",
+	".processinglang":          "file_type_processinglang.svg",
+	".procfile":                "file_type_procfile.svg",
+	".progress":                "file_type_progress.svg",
+	".prolog":                  "file_type_prolog.svg",
+	".prometheus":              "file_type_prometheus.svg",
+	".protobuf":                "file_type_protobuf.svg",
+	".protractor":              "file_type_protractor.svg",
+	".publisher":               "file_type_publisher.svg",
+	".pug":                     "file_type_pug.svg",
+	".puppet":                  "file_type_puppet.svg",
+	".purescript":              "file_type_purescript.svg",
+	".pyret":                   "file_type_pyret.svg",
+	".python":                  "file_type_python.svg",
+	".pytyped":                 "file_type_pytyped.svg",
+	".pyup":                    "file_type_pyup.svg",
+	".q":                       "file_type_q.svg",
+	".qbs":                     "file_type_qbs.svg",
+	".qlikview":                "file_type_qlikview.svg",
+	".qml":                     "file_type_qml.svg",
+	".qmldir":                  "file_type_qmldir.svg",
+	".qsharp":                  "file_type_qsharp.svg",
+	".quasar":                  "file_type_quasar.svg",
+	".r":                       "file_type_r.svg",
+	".racket":                  "file_type_racket.svg",
+	".rails":                   "file_type_rails.svg",
+	".rake":                    "file_type_rake.svg",
+	".raml":                    "file_type_raml.svg",
+	".razor":                   "file_type_razor.svg",
+	".razzle":                  "file_type_razzle.svg",
+	".reactjs":                 "file_type_reactjs.svg",
+	".reacttemplate":           "file_type_reacttemplate.svg",
+	".reactts":                 "file_type_reactts.svg",
+	".reason":                  "file_type_reason.svg",
+	".red":                     "file_type_red.svg",
+	".registry":                "file_type_registry.svg",
+	".rego":                    "file_type_rego.svg",
+	".rehype":                  "file_type_rehype.svg",
+	".remark":                  "file_type_remark.svg",
+	".renovate":                "file_type_renovate.svg",
+	".rescript":                "file_type_rescript.svg",
+	".rest":                    "file_type_rest.svg",
+	".retext":                  "file_type_retext.svg",
+	".rexx":                    "file_type_rexx.svg",
+	".riot":                    "file_type_riot.svg",
+	".rmd":                     "file_type_rmd.svg",
+	".robotframework":          "file_type_robotframework.svg",
+	".robots":                  "file_type_robots.svg",
+	".rollup":                  "file_type_rollup.svg",
+	".rproj":                   "file_type_rproj.svg",
+	".rspec":                   "file_type_rspec.svg",
+	".rubocop":                 "file_type_rubocop.svg",
+	".ruby":                    "file_type_ruby.svg",
+	".rust":                    "file_type_rust.svg",
+	".rust_toolchain":          "file_type_rust_toolchain.svg",
+	".sails":                   "file_type_sails.svg",
+	".saltstack":               "file_type_saltstack.svg",
+	".san":                     "file_type_san.svg",
+	".sas":                     "file_type_sas.svg",
+	".sass":                    "file_type_sass.svg",
+	".sbt":                     "file_type_sbt.svg",
+	".scala":                   "file_type_scala.svg",
+	".scilab":                  "file_type_scilab.svg",
+	".script":                  "file_type_script.svg",
+	".scss":                    "file_type_scss.svg",
+	".scss2":                   "file_type_scss2.svg",
+	".sdlang":                  "file_type_sdlang.svg",
+	".sentry":                  "file_type_sentry.svg",
+	".sequelize":               "file_type_sequelize.svg",
+	".serverless":              "file_type_serverless.svg",
+	".shaderlab":               "file_type_shaderlab.svg",
+	".shell":                   "file_type_shell.svg",
+	".silverstripe":            "file_type_silverstripe.svg",
+	".sketch":                  "file_type_sketch.svg",
+	".skipper":                 "file_type_skipper.svg",
+	".slang":                   "file_type_slang.svg",
+	".slice":                   "file_type_slice.svg",
+	".slim":                    "file_type_slim.svg",
+	".sln":                     "file_type_sln.svg",
+	".sln2":                    "file_type_sln2.svg",
+	".smarty":                  "file_type_smarty.svg",
+	".snapcraft":               "file_type_snapcraft.svg",
+	".snort":                   "file_type_snort.svg",
+	".snyk":                    "file_type_snyk.svg",
+	".solidarity":              "file_type_solidarity.svg",
+	".solidity":                "file_type_solidity.svg",
+	".source":                  "file_type_source.svg",
+	".spacengine":              "file_type_spacengine.svg",
+	".sparql":                  "file_type_sparql.svg",
+	".sqf":                     "file_type_sqf.svg",
+	".sql":                     "file_type_sql.svg",
+	".sqlite":                  "file_type_sqlite.svg",
+	".squirrel":                "file_type_squirrel.svg",
+	".sss":                     "file_type_sss.svg",
+	".stan":                    "file_type_stan.svg",
+	".stata":                   "file_type_stata.svg",
+	".stencil":                 "file_type_stencil.svg",
+	".storyboard":              "file_type_storyboard.svg",
+	".storybook":               "file_type_storybook.svg",
+	".stylable":                "file_type_stylable.svg",
+	".style":                   "file_type_style.svg",
+	".styled":                  "file_type_styled.svg",
+	".stylelint":               "file_type_stylelint.svg",
+	".stylish_haskell":         "file_type_stylish_haskell.svg",
+	".stylus":                  "file_type_stylus.svg",
+	".subversion":              "file_type_subversion.svg",
+	".sum":                     "file_type_go_package.svg",
+	".svelte":                  "file_type_svelte.svg",
+	".svg":                     "file_type_svg.svg",
+	".swagger":                 "file_type_swagger.svg",
+	".swift":                   "file_type_swift.svg",
+	".swig":                    "file_type_swig.svg",
+	".symfony":                 "file_type_symfony.svg",
+	".systemd":                 "file_type_systemd.svg",
+	".systemverilog":           "file_type_systemverilog.svg",
+	".t4tt":                    "file_type_t4tt.svg",
+	".tailwind":                "file_type_tailwind.svg",
+	".tar":                     "file_type_zip.svg",
+	".tcl":                     "file_type_tcl.svg",
+	".teal":                    "file_type_teal.svg",
+	".tera":                    "file_type_tera.svg",
+	".terraform":               "file_type_terraform.svg",
+	".test":                    "file_type_test.svg",
+	".testcafe":                "file_type_testcafe.svg",
+	".testjs":                  "file_type_testjs.svg",
+	".testts":                  "file_type_testts.svg",
+	".tex":                     "file_type_tex.svg",
+	".text":                    "file_type_text.svg",
+	".textile":                 "file_type_textile.svg",
+	".tfs":                     "file_type_tfs.svg",
+	".tgz":                     "file_type_zip.svg",
+	".tiff":                    "file_type_image.svg",
+	".tiltfile":                "file_type_tiltfile.svg",
+	".todo":                    "file_type_todo.svg",
+	".toml":                    "file_type_toml.svg",
+	".tox":                     "file_type_tox.svg",
+	".travis":                  "file_type_travis.svg",
+	".tsconfig":                "file_type_tsconfig.svg",
+	".tsconfig_official":       "file_type_tsconfig_official.svg",
+	".tslint":                  "file_type_tslint.svg",
+	".tt":                      "file_type_tt.svg",
+	".ttcn":                    "file_type_ttcn.svg",
+	".tuc":                     "file_type_tuc.svg",
+	".twig":                    "file_type_twig.svg",
+	".typedoc":                 "file_type_typedoc.svg",
+	".typescript":              "file_type_typescript.svg",
+	".typescript_official":     "file_type_typescript_official.svg",
+	".typescriptdef":           "file_type_typescriptdef.svg",
+	".typescriptdef_official":  "file_type_typescriptdef_official.svg",
+	".typo3":                   "file_type_typo3.svg",
+	".unibeautify":             "file_type_unibeautify.svg",
+	".unlicense":               "file_type_unlicense.svg",
+	".vagrant":                 "file_type_vagrant.svg",
+	".vala":                    "file_type_vala.svg",
+	".vanilla_extract":         "file_type_vanilla_extract.svg",
+	".vapi":                    "file_type_vapi.svg",
+	".vapor":                   "file_type_vapor.svg",
+	".vash":                    "file_type_vash.svg",
+	".vb":                      "file_type_vb.svg",
+	".vba":                     "file_type_vba.svg",
+	".vbhtml":                  "file_type_vbhtml.svg",
+	".vbproj":                  "file_type_vbproj.svg",
+	".vcxproj":                 "file_type_vcxproj.svg",
+	".velocity":                "file_type_velocity.svg",
+	".verilog":                 "file_type_verilog.svg",
+	".vhdl":                    "file_type_vhdl.svg",
+	".video":                   "file_type_video.svg",
+	".view":                    "file_type_view.svg",
+	".vim":                     "file_type_vim.svg",
+	".vite":                    "file_type_vite.svg",
+	".vlang":                   "file_type_vlang.svg",
+	".volt":                    "file_type_volt.svg",
+	".vscode":                  "file_type_vscode.svg",
+	".vscode-insiders":         "file_type_vscode-insiders.svg",
+	".vscode2":                 "file_type_vscode2.svg",
+	".vscode3":                 "file_type_vscode3.svg",
+	".vsix":                    "file_type_vsix.svg",
+	".vsixmanifest":            "file_type_vsixmanifest.svg",
+	".vue":                     "file_type_vue.svg",
+	".vueconfig":               "file_type_vueconfig.svg",
+	".wallaby":                 "file_type_wallaby.svg",
+	".wasm":                    "file_type_wasm.svg",
+	".watchmanconfig":          "file_type_watchmanconfig.svg",
+	".webp":                    "file_type_webp.svg",
+	".webpack":                 "file_type_webpack.svg",
+	".wenyan":                  "file_type_wenyan.svg",
+	".wercker":                 "file_type_wercker.svg",
+	".wolfram":                 "file_type_wolfram.svg",
+	".word":                    "file_type_word.svg",
+	".word2":                   "file_type_word2.svg",
+	".wpml":                    "file_type_wpml.svg",
+	".wurst":                   "file_type_wurst.svg",
+	".wxml":                    "file_type_wxml.svg",
+	".wxss":                    "file_type_wxss.svg",
+	".xcode":                   "file_type_xcode.svg",
+	".xfl":                     "file_type_xfl.svg",
+	".xib":                     "file_type_xib.svg",
+	".xliff":                   "file_type_xliff.svg",
+	".xmake":                   "file_type_xmake.svg",
+	".xml":                     "file_type_xml.svg",
+	".xquery":                  "file_type_xquery.svg",
+	".xsl":                     "file_type_xsl.svg",
+	".yacc":                    "file_type_yacc.svg",
+	".yaml":                    "file_type_yaml.svg",
+	".yamllint":                "file_type_yamllint.svg",
+	".yandex":                  "file_type_yandex.svg",
+	".yang":                    "file_type_yang.svg",
+	".yarn":                    "file_type_yarn.svg",
+	".yeoman":                  "file_type_yeoman.svg",
+	".zeit":                    "file_type_zeit.svg",
+	".zig":                     "file_type_zig.svg",
+	".zip":                     "file_type_zip.svg",
+	".zip2":                    "file_type_zip2.svg",
+	"access":                   "file_type_access.svg",
+	"access2":                  "file_type_access2.svg",
+	"actionscript":             "file_type_actionscript.svg",
+	"actionscript2":            "file_type_actionscript2.svg",
+	"ada":                      "file_type_ada.svg",
+	"advpl":                    "file_type_advpl.svg",
+	"affectscript":             "file_type_affectscript.svg",
+	"affinitydesigner":         "file_type_affinitydesigner.svg",
+	"affinityphoto":            "file_type_affinityphoto.svg",
+	"affinitypublisher":        "file_type_affinitypublisher.svg",
+	"ai":                       "file_type_ai.svg",
+	"ai2":                      "file_type_ai2.svg",
+	"al":                       "file_type_al.svg",
+	"angular":                  "file_type_angular.svg",
+	"ansible":                  "file_type_ansible.svg",
+	"antlr":                    "file_type_antlr.svg",
+	"anyscript":                "file_type_anyscript.svg",
+	"apache":                   "file_type_apache.svg",
+	"apex":                     "file_type_apex.svg",
+	"api_extractor":            "file_type_api_extractor.svg",
+	"apib":                     "file_type_apib.svg",
+	"apib2":                    "file_type_apib2.svg",
+	"apl":                      "file_type_apl.svg",
+	"applescript":              "file_type_applescript.svg",
+	"appsemble":                "file_type_appsemble.svg",
+	"appveyor":                 "file_type_appveyor.svg",
+	"arduino":                  "file_type_arduino.svg",
+	"asciidoc":                 "file_type_asciidoc.svg",
+	"asp":                      "file_type_asp.svg",
+	"aspx":                     "file_type_aspx.svg",
+	"assembly":                 "file_type_assembly.svg",
+	"astro":                    "file_type_astro.svg",
+	"astroconfig":              "file_type_astroconfig.svg",
+	"ats":                      "file_type_ats.svg",
+	"audio":                    "file_type_audio.svg",
+	"aurelia":                  "file_type_aurelia.svg",
+	"autohotkey":               "file_type_autohotkey.svg",
+	"autoit":                   "file_type_autoit.svg",
+	"avif":                     "file_type_avif.svg",
+	"avro":                     "file_type_avro.svg",
+	"awk":                      "file_type_awk.svg",
+	"aws":                      "file_type_aws.svg",
+	"azure":                    "file_type_azure.svg",
+	"azurepipelines":           "file_type_azurepipelines.svg",
+	"babel":                    "file_type_babel.svg",
+	"babel2":                   "file_type_babel2.svg",
+	"ballerina":                "file_type_ballerina.svg",
+	"bat":                      "file_type_bat.svg",
+	"bats":                     "file_type_bats.svg",
+	"bazaar":                   "file_type_bazaar.svg",
+	"bazel":                    "file_type_bazel.svg",
+	"befunge":                  "file_type_befunge.svg",
+	"bicep":                    "file_type_bicep.svg",
+	"biml":                     "file_type_biml.svg",
+	"binary":                   "file_type_binary.svg",
+	"bitbucketpipeline":        "file_type_bitbucketpipeline.svg",
+	"bithound":                 "file_type_bithound.svg",
+	"blade":                    "file_type_blade.svg",
+	"blitzbasic":               "file_type_blitzbasic.svg",
+	"bolt":                     "file_type_bolt.svg",
+	"bosque":                   "file_type_bosque.svg",
+	"bower":                    "file_type_bower.svg",
+	"bower2":                   "file_type_bower2.svg",
+	"browserslist":             "file_type_browserslist.svg",
+	"buckbuild":                "file_type_buckbuild.svg",
+	"bundler":                  "file_type_bundler.svg",
+	"c":                        "file_type_c.svg",
+	"c2":                       "file_type_c2.svg",
+	"c3":                       "file_type_c3.svg",
+	"c_al":                     "file_type_c_al.svg",
+	"cabal":                    "file_type_cabal.svg",
+	"caddy":                    "file_type_caddy.svg",
+	"cake":                     "file_type_cake.svg",
+	"cakephp":                  "file_type_cakephp.svg",
+	"capacitor":                "file_type_capacitor.svg",
+	"cargo":                    "file_type_cargo.svg",
+	"casc":                     "file_type_casc.svg",
+	"cddl":                     "file_type_cddl.svg",
+	"cert":                     "file_type_cert.svg",
+	"ceylon":                   "file_type_ceylon.svg",
+	"cf":                       "file_type_cf.svg",
+	"cf2":                      "file_type_cf2.svg",
+	"cfc":                      "file_type_cfc.svg",
+	"cfc2":                     "file_type_cfc2.svg",
+	"cfm":                      "file_type_cfm.svg",
+	"cfm2":                     "file_type_cfm2.svg",
+	"cheader":                  "file_type_cheader.svg",
+	"chef":                     "file_type_chef.svg",
+	"chef_cookbook":            "file_type_chef_cookbook.svg",
+	"circleci":                 "file_type_circleci.svg",
+	"class":                    "file_type_class.svg",
+	"clojure":                  "file_type_clojure.svg",
+	"clojurescript":            "file_type_clojurescript.svg",
+	"cloudfoundry":             "file_type_cloudfoundry.svg",
+	"cmake":                    "file_type_cmake.svg",
+	"cobol":                    "file_type_cobol.svg",
+	"codacy":                   "file_type_codacy.svg",
+	"codeclimate":              "file_type_codeclimate.svg",
+	"codecov":                  "file_type_codecov.svg",
+	"codekit":                  "file_type_codekit.svg",
+	"codeql":                   "file_type_codeql.svg",
+	"coffeelint":               "file_type_coffeelint.svg",
+	"coffeescript":             "file_type_coffeescript.svg",
+	"commitlint":               "file_type_commitlint.svg",
+	"compass":                  "file_type_compass.svg",
+	"composer":                 "file_type_composer.svg",
+	"conan":                    "file_type_conan.svg",
+	"conda":                    "file_type_conda.svg",
+	"config":                   "file_type_config.svg",
+	"confluence":               "file_type_confluence.svg",
+	"coveralls":                "file_type_coveralls.svg",
+	"cpp":                      "file_type_cpp.svg",
+	"cpp2":                     "file_type_cpp2.svg",
+	"cpp3":                     "file_type_cpp3.svg",
+	"cppheader":                "file_type_cppheader.svg",
+	"crowdin":                  "file_type_crowdin.svg",
+	"crystal":                  "file_type_crystal.svg",
+	"csharp":                   "file_type_csharp.svg",
+	"csharp2":                  "file_type_csharp2.svg",
+	"csproj":                   "file_type_csproj.svg",
+	"css":                      "file_type_css.svg",
+	"csscomb":                  "file_type_csscomb.svg",
+	"csslint":                  "file_type_csslint.svg",
+	"cssmap":                   "file_type_cssmap.svg",
+	"cucumber":                 "file_type_cucumber.svg",
+	"cuda":                     "file_type_cuda.svg",
+	"cvs":                      "file_type_cvs.svg",
+	"cypress":                  "file_type_cypress.svg",
+	"cython":                   "file_type_cython.svg",
+	"dal":                      "file_type_dal.svg",
+	"darcs":                    "file_type_darcs.svg",
+	"dartlang":                 "file_type_dartlang.svg",
+	"db":                       "file_type_db.svg",
+	"delphi":                   "file_type_delphi.svg",
+	"dependabot":               "file_type_dependabot.svg",
+	"dependencies":             "file_type_dependencies.svg",
+	"devcontainer":             "file_type_devcontainer.svg",
+	"diff":                     "file_type_diff.svg",
+	"django":                   "file_type_django.svg",
+	"dlang":                    "file_type_dlang.svg",
+	"docker":                   "file_type_docker.svg",
+	"docker2":                  "file_type_docker2.svg",
+	"dockerfile":               "file_type_docker2.svg",
+	"dockertest":               "file_type_dockertest.svg",
+	"dockertest2":              "file_type_dockertest2.svg",
+	"docpad":                   "file_type_docpad.svg",
+	"docz":                     "file_type_docz.svg",
+	"dojo":                     "file_type_dojo.svg",
+	"dotjs":                    "file_type_dotjs.svg",
+	"doxygen":                  "file_type_doxygen.svg",
+	"drawio":                   "file_type_drawio.svg",
+	"drone":                    "file_type_drone.svg",
+	"drools":                   "file_type_drools.svg",
+	"dustjs":                   "file_type_dustjs.svg",
+	"dvc":                      "file_type_dvc.svg",
+	"dylan":                    "file_type_dylan.svg",
+	"edge":                     "file_type_edge.svg",
+	"edge2":                    "file_type_edge2.svg",
+	"editorconfig":             "file_type_editorconfig.svg",
+	"eex":                      "file_type_eex.svg",
+	"ejs":                      "file_type_ejs.svg",
+	"elastic":                  "file_type_elastic.svg",
+	"elasticbeanstalk":         "file_type_elasticbeanstalk.svg",
+	"elixir":                   "file_type_elixir.svg",
+	"elm":                      "file_type_elm.svg",
+	"elm2":                     "file_type_elm2.svg",
+	"emacs":                    "file_type_emacs.svg",
+	"ember":                    "file_type_ember.svg",
+	"ensime":                   "file_type_ensime.svg",
+	"eps":                      "file_type_eps.svg",
+	"erb":                      "file_type_erb.svg",
+	"erlang":                   "file_type_erlang.svg",
+	"erlang2":                  "file_type_erlang2.svg",
+	"eslint":                   "file_type_eslint.svg",
+	"eslint2":                  "file_type_eslint2.svg",
+	"excel":                    "file_type_excel.svg",
+	"excel2":                   "file_type_excel2.svg",
+	"expo":                     "file_type_expo.svg",
+	"falcon":                   "file_type_falcon.svg",
+	"fauna":                    "file_type_fauna.svg",
+	"favicon":                  "file_type_favicon.svg",
+	"fbx":                      "file_type_fbx.svg",
+	"firebase":                 "file_type_firebase.svg",
+	"firebasehosting":          "file_type_firebasehosting.svg",
+	"firestore":                "file_type_firestore.svg",
+	"fla":                      "file_type_fla.svg",
+	"flash":                    "file_type_flash.svg",
+	"floobits":                 "file_type_floobits.svg",
+	"flow":                     "file_type_flow.svg",
+	"flutter":                  "file_type_flutter.svg",
+	"flutter_package":          "file_type_flutter_package.svg",
+	"font":                     "file_type_font.svg",
+	"fortran":                  "file_type_fortran.svg",
+	"fossa":                    "file_type_fossa.svg",
+	"fossil":                   "file_type_fossil.svg",
+	"freemarker":               "file_type_freemarker.svg",
+	"fsharp":                   "file_type_fsharp.svg",
+	"fsharp2":                  "file_type_fsharp2.svg",
+	"fsproj":                   "file_type_fsproj.svg",
+	"fthtml":                   "file_type_fthtml.svg",
+	"fusebox":                  "file_type_fusebox.svg",
+	"galen":                    "file_type_galen.svg",
+	"galen2":                   "file_type_galen2.svg",
+	"gamemaker":                "file_type_gamemaker.svg",
+	"gamemaker2":               "file_type_gamemaker2.svg",
+	"gamemaker81":              "file_type_gamemaker81.svg",
+	"gatsby":                   "file_type_gatsby.svg",
+	"gcode":                    "file_type_gcode.svg",
+	"gemfile":                  "file_type_bundler.svg",
+	"genstat":                  "file_type_genstat.svg",
+	"git":                      "file_type_git.svg",
+	"git2":                     "file_type_git2.svg",
+	"gitlab":                   "file_type_gitlab.svg",
+	"gitpod":                   "file_type_gitpod.svg",
+	"glide":                    "file_type_glide.svg",
+	"glitter":                  "file_type_glitter.svg",
+	"glsl":                     "file_type_glsl.svg",
+	"glyphs":                   "file_type_glyphs.svg",
+	"gnuplot":                  "file_type_gnuplot.svg",
+	"go":                       "file_type_go.svg",
+	"go_aqua":                  "file_type_go_aqua.svg",
+	"go_black":                 "file_type_go_black.svg",
+	"go_fuchsia":               "file_type_go_fuchsia.svg",
+	"go_gopher":                "file_type_go_gopher.svg",
+	"go_lightblue":             "file_type_go_lightblue.svg",
+	"go_package":               "file_type_go_package.svg",
+	"go_white":                 "file_type_go_white.svg",
+	"go_yellow":                "file_type_go_yellow.svg",
+	"godot":                    "file_type_godot.svg",
+	"gradle":                   "file_type_gradle.svg",
+	"gradle2":                  "file_type_gradle2.svg",
+	"graphql":                  "file_type_graphql.svg",
+	"graphql_config":           "file_type_graphql_config.svg",
+	"graphviz":                 "file_type_graphviz.svg",
+	"greenkeeper":              "file_type_greenkeeper.svg",
+	"gridsome":                 "file_type_gridsome.svg",
+	"groovy":                   "file_type_groovy.svg",
+	"groovy2":                  "file_type_groovy2.svg",
+	"grunt":                    "file_type_grunt.svg",
+	"gulp":                     "file_type_gulp.svg",
+	"haml":                     "file_type_haml.svg",
+	"handlebars":               "file_type_handlebars.svg",
+	"handlebars2":              "file_type_handlebars2.svg",
+	"harbour":                  "file_type_harbour.svg",
+	"haskell":                  "file_type_haskell.svg",
+	"haskell2":                 "file_type_haskell2.svg",
+	"haxe":                     "file_type_haxe.svg",
+	"haxecheckstyle":           "file_type_haxecheckstyle.svg",
+	"haxedevelop":              "file_type_haxedevelop.svg",
+	"helix":                    "file_type_helix.svg",
+	"helm":                     "file_type_helm.svg",
+	"hjson":                    "file_type_hjson.svg",
+	"hlsl":                     "file_type_hlsl.svg",
+	"homeassistant":            "file_type_homeassistant.svg",
+	"horusec":                  "file_type_horusec.svg",
+	"host":                     "file_type_host.svg",
+	"html":                     "file_type_html.svg",
+	"htmlhint":                 "file_type_htmlhint.svg",
+	"http":                     "file_type_http.svg",
+	"hunspell":                 "file_type_hunspell.svg",
+	"husky":                    "file_type_husky.svg",
+	"hy":                       "file_type_hy.svg",
+	"hygen":                    "file_type_hygen.svg",
+	"hypr":                     "file_type_hypr.svg",
+	"icl":                      "file_type_icl.svg",
+	"idris":                    "file_type_idris.svg",
+	"idrisbin":                 "file_type_idrisbin.svg",
+	"idrispkg":                 "file_type_idrispkg.svg",
+	"image":                    "file_type_image.svg",
+	"imba":                     "file_type_imba.svg",
+	"inc":                      "file_type_inc.svg",
+	"infopath":                 "file_type_infopath.svg",
+	"informix":                 "file_type_informix.svg",
+	"ini":                      "file_type_ini.svg",
+	"ink":                      "file_type_ink.svg",
+	"innosetup":                "file_type_innosetup.svg",
+	"io":                       "file_type_io.svg",
+	"iodine":                   "file_type_iodine.svg",
+	"ionic":                    "file_type_ionic.svg",
+	"jake":                     "file_type_jake.svg",
+	"janet":                    "file_type_janet.svg",
+	"jar":                      "file_type_jar.svg",
+	"jasmine":                  "file_type_jasmine.svg",
+	"java":                     "file_type_java.svg",
+	"jbuilder":                 "file_type_jbuilder.svg",
+	"jekyll":                   "file_type_jekyll.svg",
+	"jenkins":                  "file_type_jenkins.svg",
+	"jest":                     "file_type_jest.svg",
+	"jest_snapshot":            "file_type_jest_snapshot.svg",
+	"jinja":                    "file_type_jinja.svg",
+	"jpm":                      "file_type_jpm.svg",
+	"js":                       "file_type_js.svg",
+	"js_official":              "file_type_js_official.svg",
+	"jsbeautify":               "file_type_jsbeautify.svg",
+	"jsconfig":                 "file_type_jsconfig.svg",
+	"jscpd":                    "file_type_jscpd.svg",
+	"jshint":                   "file_type_jshint.svg",
+	"jsmap":                    "file_type_jsmap.svg",
+	"json":                     "file_type_json.svg",
+	"json2":                    "file_type_json2.svg",
+	"json5":                    "file_type_json5.svg",
+	"json_official":            "file_type_json_official.svg",
+	"jsonld":                   "file_type_jsonld.svg",
+	"jsonnet":                  "file_type_jsonnet.svg",
+	"jsp":                      "file_type_jsp.svg",
+	"jss":                      "file_type_jss.svg",
+	"julia":                    "file_type_julia.svg",
+	"julia2":                   "file_type_julia2.svg",
+	"jupyter":                  "file_type_jupyter.svg",
+	"karma":                    "file_type_karma.svg",
+	"key":                      "file_type_key.svg",
+	"kitchenci":                "file_type_kitchenci.svg",
+	"kite":                     "file_type_kite.svg",
+	"kivy":                     "file_type_kivy.svg",
+	"kos":                      "file_type_kos.svg",
+	"kotlin":                   "file_type_kotlin.svg",
+	"kusto":                    "file_type_kusto.svg",
+	"latino":                   "file_type_latino.svg",
+	"layout":                   "file_type_layout.svg",
+	"lerna":                    "file_type_lerna.svg",
+	"less":                     "file_type_less.svg",
+	"lex":                      "file_type_lex.svg",
+	"license":                  "file_type_license.svg",
+	"licensebat":               "file_type_licensebat.svg",
+	"light_actionscript2":      "file_type_light_actionscript2.svg",
+	"light_ada":                "file_type_light_ada.svg",
+	"light_apl":                "file_type_light_apl.svg",
+	"light_babel":              "file_type_light_babel.svg",
+	"light_babel2":             "file_type_light_babel2.svg",
+	"light_cabal":              "file_type_light_cabal.svg",
+	"light_circleci":           "file_type_light_circleci.svg",
+	"light_cloudfoundry":       "file_type_light_cloudfoundry.svg",
+	"light_codacy":             "file_type_light_codacy.svg",
+	"light_codeclimate":        "file_type_light_codeclimate.svg",
+	"light_config":             "file_type_light_config.svg",
+	"light_crystal":            "file_type_light_crystal.svg",
+	"light_db":                 "file_type_light_db.svg",
+	"light_docpad":             "file_type_light_docpad.svg",
+	"light_drone":              "file_type_light_drone.svg",
+	"light_expo":               "file_type_light_expo.svg",
+	"light_firebasehosting":    "file_type_light_firebasehosting.svg",
+	"light_fla":                "file_type_light_fla.svg",
+	"light_font":               "file_type_light_font.svg",
+	"light_gamemaker2":         "file_type_light_gamemaker2.svg",
+	"light_gradle":             "file_type_light_gradle.svg",
+	"light_hjson":              "file_type_light_hjson.svg",
+	"light_ini":                "file_type_light_ini.svg",
+	"light_io":                 "file_type_light_io.svg",
+	"light_js":                 "file_type_light_js.svg",
+	"light_jsconfig":           "file_type_light_jsconfig.svg",
+	"light_jsmap":              "file_type_light_jsmap.svg",
+	"light_json":               "file_type_light_json.svg",
+	"light_json5":              "file_type_light_json5.svg",
+	"light_jsonld":             "file_type_light_jsonld.svg",
+	"light_kite":               "file_type_light_kite.svg",
+	"light_lerna":              "file_type_light_lerna.svg",
+	"light_mdx":                "file_type_light_mdx.svg",
+	"light_mlang":              "file_type_light_mlang.svg",
+	"light_mustache":           "file_type_light_mustache.svg",
+	"light_next":               "file_type_light_next.svg",
+	"light_nim":                "file_type_light_nim.svg",
+	"light_openHAB":            "file_type_light_openHAB.svg",
+	"light_pcl":                "file_type_light_pcl.svg",
+	"light_pnpm":               "file_type_light_pnpm.svg",
+	"light_prettier":           "file_type_light_prettier.svg",
+	"light_prisma":             "file_type_light_prisma.svg",
+	"light_purescript":         "file_type_light_purescript.svg",
+	"light_razzle":             "file_type_light_razzle.svg",
+	"light_rehype":             "file_type_light_rehype.svg",
+	"light_remark":             "file_type_light_remark.svg",
+	"light_retext":             "file_type_light_retext.svg",
+	"light_rubocop":            "file_type_light_rubocop.svg",
+	"light_rust":               "file_type_light_rust.svg",
+	"light_rust_toolchain":     "file_type_light_rust_toolchain.svg",
+	"light_shaderlab":          "file_type_light_shaderlab.svg",
+	"light_solidity":           "file_type_light_solidity.svg",
+	"light_stylelint":          "file_type_light_stylelint.svg",
+	"light_stylus":             "file_type_light_stylus.svg",
+	"light_symfony":            "file_type_light_symfony.svg",
+	"light_systemd":            "file_type_light_systemd.svg",
+	"light_systemverilog":      "file_type_light_systemverilog.svg",
+	"light_testcafe":           "file_type_light_testcafe.svg",
+	"light_testjs":             "file_type_light_testjs.svg",
+	"light_tex":                "file_type_light_tex.svg",
+	"light_todo":               "file_type_light_todo.svg",
+	"light_toml":               "file_type_light_toml.svg",
+	"light_unibeautify":        "file_type_light_unibeautify.svg",
+	"light_vash":               "file_type_light_vash.svg",
+	"light_vsix":               "file_type_light_vsix.svg",
+	"light_vsixmanifest":       "file_type_light_vsixmanifest.svg",
+	"light_xfl":                "file_type_light_xfl.svg",
+	"light_yaml":               "file_type_light_yaml.svg",
+	"light_zeit":               "file_type_light_zeit.svg",
+	"lighthouse":               "file_type_lighthouse.svg",
+	"lime":                     "file_type_lime.svg",
+	"lintstagedrc":             "file_type_lintstagedrc.svg",
+	"liquid":                   "file_type_liquid.svg",
+	"lisp":                     "file_type_lisp.svg",
+	"livescript":               "file_type_livescript.svg",
+	"lnk":                      "file_type_lnk.svg",
+	"locale":                   "file_type_locale.svg",
+	"log":                      "file_type_log.svg",
+	"lolcode":                  "file_type_lolcode.svg",
+	"lsl":                      "file_type_lsl.svg",
+	"lua":                      "file_type_lua.svg",
+	"lync":                     "file_type_lync.svg",
+	"makefile":                 "file_type_makefile.svg",
+	"manifest":                 "file_type_manifest.svg",
+	"manifest_bak":             "file_type_manifest_bak.svg",
+	"manifest_skip":            "file_type_manifest_skip.svg",
+	"map":                      "file_type_map.svg",
+	"mariadb":                  "file_type_mariadb.svg",
+	"markdown":                 "file_type_markdown.svg",
+	"markdownlint":             "file_type_markdownlint.svg",
+	"marko":                    "file_type_marko.svg",
+	"markojs":                  "file_type_markojs.svg",
+	"matlab":                   "file_type_matlab.svg",
+	"maven":                    "file_type_maven.svg",
+	"maxscript":                "file_type_maxscript.svg",
+	"maya":                     "file_type_maya.svg",
+	"mdx":                      "file_type_mdx.svg",
+	"mediawiki":                "file_type_mediawiki.svg",
+	"mercurial":                "file_type_mercurial.svg",
+	"meson":                    "file_type_meson.svg",
+	"meteor":                   "file_type_meteor.svg",
+	"mjml":                     "file_type_mjml.svg",
+	"mlang":                    "file_type_mlang.svg",
+	"mocha":                    "file_type_mocha.svg",
+	"modernizr":                "file_type_modernizr.svg",
+	"mojolicious":              "file_type_mojolicious.svg",
+	"moleculer":                "file_type_moleculer.svg",
+	"mongo":                    "file_type_mongo.svg",
+	"monotone":                 "file_type_monotone.svg",
+	"mson":                     "file_type_mson.svg",
+	"mustache":                 "file_type_mustache.svg",
+	"mysql":                    "file_type_mysql.svg",
+	"nearly":                   "file_type_nearly.svg",
+	"nest_adapter_js":          "file_type_nest_adapter_js.svg",
+	"nest_adapter_ts":          "file_type_nest_adapter_ts.svg",
+	"nest_controller_js":       "file_type_nest_controller_js.svg",
+	"nest_controller_ts":       "file_type_nest_controller_ts.svg",
+	"nest_decorator_js":        "file_type_nest_decorator_js.svg",
+	"nest_decorator_ts":        "file_type_nest_decorator_ts.svg",
+	"nest_filter_js":           "file_type_nest_filter_js.svg",
+	"nest_filter_ts":           "file_type_nest_filter_ts.svg",
+	"nest_gateway_js":          "file_type_nest_gateway_js.svg",
+	"nest_gateway_ts":          "file_type_nest_gateway_ts.svg",
+	"nest_guard_js":            "file_type_nest_guard_js.svg",
+	"nest_guard_ts":            "file_type_nest_guard_ts.svg",
+	"nest_interceptor_js":      "file_type_nest_interceptor_js.svg",
+	"nest_interceptor_ts":      "file_type_nest_interceptor_ts.svg",
+	"nest_middleware_js":       "file_type_nest_middleware_js.svg",
+	"nest_middleware_ts":       "file_type_nest_middleware_ts.svg",
+	"nest_module_js":           "file_type_nest_module_js.svg",
+	"nest_module_ts":           "file_type_nest_module_ts.svg",
+	"nest_pipe_js":             "file_type_nest_pipe_js.svg",
+	"nest_pipe_ts":             "file_type_nest_pipe_ts.svg",
+	"nest_service_js":          "file_type_nest_service_js.svg",
+	"nest_service_ts":          "file_type_nest_service_ts.svg",
+	"nestjs":                   "file_type_nestjs.svg",
+	"netlify":                  "file_type_netlify.svg",
+	"next":                     "file_type_next.svg",
+	"ng_component_css":         "file_type_ng_component_css.svg",
+	"ng_component_dart":        "file_type_ng_component_dart.svg",
+	"ng_component_html":        "file_type_ng_component_html.svg",
+	"ng_component_js":          "file_type_ng_component_js.svg",
+	"ng_component_js2":         "file_type_ng_component_js2.svg",
+	"ng_component_less":        "file_type_ng_component_less.svg",
+	"ng_component_sass":        "file_type_ng_component_sass.svg",
+	"ng_component_scss":        "file_type_ng_component_scss.svg",
+	"ng_component_ts":          "file_type_ng_component_ts.svg",
+	"ng_component_ts2":         "file_type_ng_component_ts2.svg",
+	"ng_controller_js":         "file_type_ng_controller_js.svg",
+	"ng_controller_ts":         "file_type_ng_controller_ts.svg",
+	"ng_directive_dart":        "file_type_ng_directive_dart.svg",
+	"ng_directive_js":          "file_type_ng_directive_js.svg",
+	"ng_directive_js2":         "file_type_ng_directive_js2.svg",
+	"ng_directive_ts":          "file_type_ng_directive_ts.svg",
+	"ng_directive_ts2":         "file_type_ng_directive_ts2.svg",
+	"ng_guard_dart":            "file_type_ng_guard_dart.svg",
+	"ng_guard_js":              "file_type_ng_guard_js.svg",
+	"ng_guard_ts":              "file_type_ng_guard_ts.svg",
+	"ng_interceptor_dart":      "file_type_ng_interceptor_dart.svg",
+	"ng_interceptor_js":        "file_type_ng_interceptor_js.svg",
+	"ng_interceptor_ts":        "file_type_ng_interceptor_ts.svg",
+	"ng_module_dart":           "file_type_ng_module_dart.svg",
+	"ng_module_js":             "file_type_ng_module_js.svg",
+	"ng_module_js2":            "file_type_ng_module_js2.svg",
+	"ng_module_ts":             "file_type_ng_module_ts.svg",
+	"ng_module_ts2":            "file_type_ng_module_ts2.svg",
+	"ng_pipe_dart":             "file_type_ng_pipe_dart.svg",
+	"ng_pipe_js":               "file_type_ng_pipe_js.svg",
+	"ng_pipe_js2":              "file_type_ng_pipe_js2.svg",
+	"ng_pipe_ts":               "file_type_ng_pipe_ts.svg",
+	"ng_pipe_ts2":              "file_type_ng_pipe_ts2.svg",
+	"ng_routing_dart":          "file_type_ng_routing_dart.svg",
+	"ng_routing_js":            "file_type_ng_routing_js.svg",
+	"ng_routing_js2":           "file_type_ng_routing_js2.svg",
+	"ng_routing_ts":            "file_type_ng_routing_ts.svg",
+	"ng_routing_ts2":           "file_type_ng_routing_ts2.svg",
+	"ng_service_dart":          "file_type_ng_service_dart.svg",
+	"ng_service_js":            "file_type_ng_service_js.svg",
+	"ng_service_js2":           "file_type_ng_service_js2.svg",
+	"ng_service_ts":            "file_type_ng_service_ts.svg",
+	"ng_service_ts2":           "file_type_ng_service_ts2.svg",
+	"ng_smart_component_dart":  "file_type_ng_smart_component_dart.svg",
+	"ng_smart_component_js":    "file_type_ng_smart_component_js.svg",
+	"ng_smart_component_js2":   "file_type_ng_smart_component_js2.svg",
+	"ng_smart_component_ts":    "file_type_ng_smart_component_ts.svg",
+	"ng_smart_component_ts2":   "file_type_ng_smart_component_ts2.svg",
+	"ng_tailwind":              "file_type_ng_tailwind.svg",
+	"nginx":                    "file_type_nginx.svg",
+	"nim":                      "file_type_nim.svg",
+	"nimble":                   "file_type_nimble.svg",
+	"ninja":                    "file_type_ninja.svg",
+	"nix":                      "file_type_nix.svg",
+	"njsproj":                  "file_type_njsproj.svg",
+	"node":                     "file_type_node.svg",
+	"node2":                    "file_type_node2.svg",
+	"nodemon":                  "file_type_nodemon.svg",
+	"npm":                      "file_type_npm.svg",
+	"nsi":                      "file_type_nsi.svg",
+	"nsri":                     "file_type_nsri.svg",
+	"nsri-integrity":           "file_type_nsri-integrity.svg",
+	"nuget":                    "file_type_nuget.svg",
+	"numpy":                    "file_type_numpy.svg",
+	"nunjucks":                 "file_type_nunjucks.svg",
+	"nuxt":                     "file_type_nuxt.svg",
+	"nyc":                      "file_type_nyc.svg",
+	"objectivec":               "file_type_objectivec.svg",
+	"objectivecpp":             "file_type_objectivecpp.svg",
+	"ocaml":                    "file_type_ocaml.svg",
+	"ogone":                    "file_type_ogone.svg",
+	"onenote":                  "file_type_onenote.svg",
+	"openHAB":                  "file_type_openHAB.svg",
+	"opencl":                   "file_type_opencl.svg",
+	"openscad":                 "file_type_openscad.svg",
+	"org":                      "file_type_org.svg",
+	"outlook":                  "file_type_outlook.svg",
+	"ovpn":                     "file_type_ovpn.svg",
+	"package":                  "file_type_package.svg",
+	"paket":                    "file_type_paket.svg",
+	"patch":                    "file_type_patch.svg",
+	"pcl":                      "file_type_pcl.svg",
+	"pddl":                     "file_type_pddl.svg",
+	"pddl_happenings":          "file_type_pddl_happenings.svg",
+	"pddl_plan":                "file_type_pddl_plan.svg",
+	"pdf":                      "file_type_pdf.svg",
+	"pdf2":                     "file_type_pdf2.svg",
+	"perl":                     "file_type_perl.svg",
+	"perl2":                    "file_type_perl2.svg",
+	"perl6":                    "file_type_perl6.svg",
+	"pgsql":                    "file_type_pgsql.svg",
+	"photoshop":                "file_type_photoshop.svg",
+	"photoshop2":               "file_type_photoshop2.svg",
+	"php":                      "file_type_php.svg",
+	"php2":                     "file_type_php2.svg",
+	"php3":                     "file_type_php3.svg",
+	"phpcsfixer":               "file_type_phpcsfixer.svg",
+	"phpunit":                  "file_type_phpunit.svg",
+	"phraseapp":                "file_type_phraseapp.svg",
+	"pine":                     "file_type_pine.svg",
+	"pip":                      "file_type_pip.svg",
+	"pipeline":                 "file_type_pipeline.svg",
+	"plantuml":                 "file_type_plantuml.svg",
+	"platformio":               "file_type_platformio.svg",
+	"plsql":                    "file_type_plsql.svg",
+	"plsql_package":            "file_type_plsql_package.svg",
+	"plsql_package_body":       "file_type_plsql_package_body.svg",
+	"plsql_package_header":     "file_type_plsql_package_header.svg",
+	"plsql_package_spec":       "file_type_plsql_package_spec.svg",
+	"pnpm":                     "file_type_pnpm.svg",
+	"poedit":                   "file_type_poedit.svg",
+	"polymer":                  "file_type_polymer.svg",
+	"pony":                     "file_type_pony.svg",
+	"postcss":                  "file_type_postcss.svg",
+	"postcssconfig":            "file_type_postcssconfig.svg",
+	"powerpoint":               "file_type_powerpoint.svg",
+	"powerpoint2":              "file_type_powerpoint2.svg",
+	"powershell":               "file_type_powershell.svg",
+	"powershell2":              "file_type_powershell2.svg",
+	"powershell_format":        "file_type_powershell_format.svg",
+	"powershell_psd":           "file_type_powershell_psd.svg",
+	"powershell_psd2":          "file_type_powershell_psd2.svg",
+	"powershell_psm":           "file_type_powershell_psm.svg",
+	"powershell_psm2":          "file_type_powershell_psm2.svg",
+	"powershell_types":         "file_type_powershell_types.svg",
+	"preact":                   "file_type_preact.svg",
+	"precommit":                "file_type_precommit.svg",
+	"prettier":                 "file_type_prettier.svg",
+	"prisma":                   "file_type_prisma.svg",
+	"processinglang":           "file_type_processinglang.svg",
+	"procfile":                 "file_type_procfile.svg",
+	"progress":                 "file_type_progress.svg",
+	"prolog":                   "file_type_prolog.svg",
+	"prometheus":               "file_type_prometheus.svg",
+	"protobuf":                 "file_type_protobuf.svg",
+	"protractor":               "file_type_protractor.svg",
+	"publisher":                "file_type_publisher.svg",
+	"pug":                      "file_type_pug.svg",
+	"puppet":                   "file_type_puppet.svg",
+	"purescript":               "file_type_purescript.svg",
+	"pyret":                    "file_type_pyret.svg",
+	"python":                   "file_type_python.svg",
+	"pytyped":                  "file_type_pytyped.svg",
+	"pyup":                     "file_type_pyup.svg",
+	"q":                        "file_type_q.svg",
+	"qbs":                      "file_type_qbs.svg",
+	"qlikview":                 "file_type_qlikview.svg",
+	"qml":                      "file_type_qml.svg",
+	"qmldir":                   "file_type_qmldir.svg",
+	"qsharp":                   "file_type_qsharp.svg",
+	"quasar":                   "file_type_quasar.svg",
+	"r":                        "file_type_r.svg",
+	"racket":                   "file_type_racket.svg",
+	"rails":                    "file_type_rails.svg",
+	"rake":                     "file_type_rake.svg",
+	"raml":                     "file_type_raml.svg",
+	"razor":                    "file_type_razor.svg",
+	"razzle":                   "file_type_razzle.svg",
+	"reactjs":                  "file_type_reactjs.svg",
+	"reacttemplate":            "file_type_reacttemplate.svg",
+	"reactts":                  "file_type_reactts.svg",
+	"reason":                   "file_type_reason.svg",
+	"red":                      "file_type_red.svg",
+	"registry":                 "file_type_registry.svg",
+	"rego":                     "file_type_rego.svg",
+	"rehype":                   "file_type_rehype.svg",
+	"remark":                   "file_type_remark.svg",
+	"renovate":                 "file_type_renovate.svg",
+	"rescript":                 "file_type_rescript.svg",
+	"rest":                     "file_type_rest.svg",
+	"retext":                   "file_type_retext.svg",
+	"rexx":                     "file_type_rexx.svg",
+	"riot":                     "file_type_riot.svg",
+	"rmd":                      "file_type_rmd.svg",
+	"robotframework":           "file_type_robotframework.svg",
+	"robots":                   "file_type_robots.svg",
+	"rollup":                   "file_type_rollup.svg",
+	"rproj":                    "file_type_rproj.svg",
+	"rspec":                    "file_type_rspec.svg",
+	"rubocop":                  "file_type_rubocop.svg",
+	"ruby":                     "file_type_ruby.svg",
+	"rust":                     "file_type_rust.svg",
+	"rust_toolchain":           "file_type_rust_toolchain.svg",
+	"sails":                    "file_type_sails.svg",
+	"saltstack":                "file_type_saltstack.svg",
+	"san":                      "file_type_san.svg",
+	"sas":                      "file_type_sas.svg",
+	"sass":                     "file_type_sass.svg",
+	"sbt":                      "file_type_sbt.svg",
+	"scala":                    "file_type_scala.svg",
+	"scilab":                   "file_type_scilab.svg",
+	"script":                   "file_type_script.svg",
+	"scss":                     "file_type_scss.svg",
+	"scss2":                    "file_type_scss2.svg",
+	"sdlang":                   "file_type_sdlang.svg",
+	"sentry":                   "file_type_sentry.svg",
+	"sequelize":                "file_type_sequelize.svg",
+	"serverless":               "file_type_serverless.svg",
+	"shaderlab":                "file_type_shaderlab.svg",
+	"shell":                    "file_type_shell.svg",
+	"silverstripe":             "file_type_silverstripe.svg",
+	"sketch":                   "file_type_sketch.svg",
+	"skipper":                  "file_type_skipper.svg",
+	"slang":                    "file_type_slang.svg",
+	"slice":                    "file_type_slice.svg",
+	"slim":                     "file_type_slim.svg",
+	"sln":                      "file_type_sln.svg",
+	"sln2":                     "file_type_sln2.svg",
+	"smarty":                   "file_type_smarty.svg",
+	"snapcraft":                "file_type_snapcraft.svg",
+	"snort":                    "file_type_snort.svg",
+	"snyk":                     "file_type_snyk.svg",
+	"solidarity":               "file_type_solidarity.svg",
+	"solidity":                 "file_type_solidity.svg",
+	"source":                   "file_type_source.svg",
+	"spacengine":               "file_type_spacengine.svg",
+	"sparql":                   "file_type_sparql.svg",
+	"sqf":                      "file_type_sqf.svg",
+	"sql":                      "file_type_sql.svg",
+	"sqlite":                   "file_type_sqlite.svg",
+	"squirrel":                 "file_type_squirrel.svg",
+	"sss":                      "file_type_sss.svg",
+	"stan":                     "file_type_stan.svg",
+	"stata":                    "file_type_stata.svg",
+	"stencil":                  "file_type_stencil.svg",
+	"storyboard":               "file_type_storyboard.svg",
+	"storybook":                "file_type_storybook.svg",
+	"stylable":                 "file_type_stylable.svg",
+	"style":                    "file_type_style.svg",
+	"styled":                   "file_type_styled.svg",
+	"stylelint":                "file_type_stylelint.svg",
+	"stylish_haskell":          "file_type_stylish_haskell.svg",
+	"stylus":                   "file_type_stylus.svg",
+	"subversion":               "file_type_subversion.svg",
+	"svelte":                   "file_type_svelte.svg",
+	"svg":                      "file_type_svg.svg",
+	"swagger":                  "file_type_swagger.svg",
+	"swift":                    "file_type_swift.svg",
+	"swig":                     "file_type_swig.svg",
+	"symfony":                  "file_type_symfony.svg",
+	"systemd":                  "file_type_systemd.svg",
+	"systemverilog":            "file_type_systemverilog.svg",
+	"t4tt":                     "file_type_t4tt.svg",
+	"tailwind":                 "file_type_tailwind.svg",
+	"tcl":                      "file_type_tcl.svg",
+	"teal":                     "file_type_teal.svg",
+	"tera":                     "file_type_tera.svg",
+	"terraform":                "file_type_terraform.svg",
+	"test":                     "file_type_test.svg",
+	"testcafe":                 "file_type_testcafe.svg",
+	"testjs":                   "file_type_testjs.svg",
+	"testts":                   "file_type_testts.svg",
+	"tex":                      "file_type_tex.svg",
+	"text":                     "file_type_text.svg",
+	"textile":                  "file_type_textile.svg",
+	"tfs":                      "file_type_tfs.svg",
+	"tiltfile":                 "file_type_tiltfile.svg",
+	"todo":                     "file_type_todo.svg",
+	"toml":                     "file_type_toml.svg",
+	"tox":                      "file_type_tox.svg",
+	"travis":                   "file_type_travis.svg",
+	"tsconfig":                 "file_type_tsconfig.svg",
+	"tsconfig_official":        "file_type_tsconfig_official.svg",
+	"tslint":                   "file_type_tslint.svg",
+	"tt":                       "file_type_tt.svg",
+	"ttcn":                     "file_type_ttcn.svg",
+	"tuc":                      "file_type_tuc.svg",
+	"twig":                     "file_type_twig.svg",
+	"typedoc":                  "file_type_typedoc.svg",
+	"typescript":               "file_type_typescript.svg",
+	"typescript_official":      "file_type_typescript_official.svg",
+	"typescriptdef":            "file_type_typescriptdef.svg",
+	"typescriptdef_official":   "file_type_typescriptdef_official.svg",
+	"typo3":                    "file_type_typo3.svg",
+	"unibeautify":              "file_type_unibeautify.svg",
+	"unlicense":                "file_type_unlicense.svg",
+	"vagrant":                  "file_type_vagrant.svg",
+	"vala":                     "file_type_vala.svg",
+	"vanilla_extract":          "file_type_vanilla_extract.svg",
+	"vapi":                     "file_type_vapi.svg",
+	"vapor":                    "file_type_vapor.svg",
+	"vash":                     "file_type_vash.svg",
+	"vb":                       "file_type_vb.svg",
+	"vba":                      "file_type_vba.svg",
+	"vbhtml":                   "file_type_vbhtml.svg",
+	"vbproj":                   "file_type_vbproj.svg",
+	"vcxproj":                  "file_type_vcxproj.svg",
+	"velocity":                 "file_type_velocity.svg",
+	"verilog":                  "file_type_verilog.svg",
+	"vhdl":                     "file_type_vhdl.svg",
+	"video":                    "file_type_video.svg",
+	"view":                     "file_type_view.svg",
+	"vim":                      "file_type_vim.svg",
+	"vite":                     "file_type_vite.svg",
+	"vlang":                    "file_type_vlang.svg",
+	"volt":                     "file_type_volt.svg",
+	"vscode":                   "file_type_vscode.svg",
+	"vscode-insiders":          "file_type_vscode-insiders.svg",
+	"vscode2":                  "file_type_vscode2.svg",
+	"vscode3":                  "file_type_vscode3.svg",
+	"vsix":                     "file_type_vsix.svg",
+	"vsixmanifest":             "file_type_vsixmanifest.svg",
+	"vue":                      "file_type_vue.svg",
+	"vueconfig":                "file_type_vueconfig.svg",
+	"wallaby":                  "file_type_wallaby.svg",
+	"wasm":                     "file_type_wasm.svg",
+	"watchmanconfig":           "file_type_watchmanconfig.svg",
+	"webp":                     "file_type_webp.svg",
+	"webpack":                  "file_type_webpack.svg",
+	"wenyan":                   "file_type_wenyan.svg",
+	"wercker":                  "file_type_wercker.svg",
+	"wolfram":                  "file_type_wolfram.svg",
+	"word":                     "file_type_word.svg",
+	"word2":                    "file_type_word2.svg",
+	"wpml":                     "file_type_wpml.svg",
+	"wurst":                    "file_type_wurst.svg",
+	"wxml":                     "file_type_wxml.svg",
+	"wxss":                     "file_type_wxss.svg",
+	"xcode":                    "file_type_xcode.svg",
+	"xfl":                      "file_type_xfl.svg",
+	"xib":                      "file_type_xib.svg",
+	"xliff":                    "file_type_xliff.svg",
+	"xmake":                    "file_type_xmake.svg",
+	"xml":                      "file_type_xml.svg",
+	"xquery":                   "file_type_xquery.svg",
+	"xsl":                      "file_type_xsl.svg",
+	"yacc":                     "file_type_yacc.svg",
+	"yaml":                     "file_type_yaml.svg",
+	"yamllint":                 "file_type_yamllint.svg",
+	"yandex":                   "file_type_yandex.svg",
+	"yang":                     "file_type_yang.svg",
+	"yarn":                     "file_type_yarn.svg",
+	"yeoman":                   "file_type_yeoman.svg",
+	"zeit":                     "file_type_zeit.svg",
+	"zig":                      "file_type_zig.svg",
+	"zip":                      "file_type_zip.svg",
+	"zip2":                     "file_type_zip2.svg",
+}
+
+// folderIcons maps a folder name to an icon under /static/icons.
+var folderIcons = map[string]string{
+	"":                        "default_folder.svg",
+	"android":                 "folder_type_android.svg",
+	"android_opened":          "folder_type_android_opened.svg",
+	"api":                     "folder_type_api.svg",
+	"api_opened":              "folder_type_api_opened.svg",
+	"app":                     "folder_type_app.svg",
+	"app_opened":              "folder_type_app_opened.svg",
+	"arangodb":                "folder_type_arangodb.svg",
+	"arangodb_opened":         "folder_type_arangodb_opened.svg",
+	"asset":                   "folder_type_asset.svg",
+	"asset_opened":            "folder_type_asset_opened.svg",
+	"audio":                   "folder_type_audio.svg",
+	"audio_opened":            "folder_type_audio_opened.svg",
+	"aurelia":                 "folder_type_aurelia.svg",
+	"aurelia_opened":          "folder_type_aurelia_opened.svg",
+	"aws":                     "folder_type_aws.svg",
+	"aws_opened":              "folder_type_aws_opened.svg",
+	"azure":                   "folder_type_azure.svg",
+	"azure_opened":            "folder_type_azure_opened.svg",
+	"azurepipelines":          "folder_type_azurepipelines.svg",
+	"azurepipelines_opened":   "folder_type_azurepipelines_opened.svg",
+	"binary":                  "folder_type_binary.svg",
+	"binary_opened":           "folder_type_binary_opened.svg",
+	"bloc":                    "folder_type_bloc.svg",
+	"bloc_opened":             "folder_type_bloc_opened.svg",
+	"blueprint":               "folder_type_blueprint.svg",
+	"blueprint_opened":        "folder_type_blueprint_opened.svg",
+	"bower":                   "folder_type_bower.svg",
+	"bower_opened":            "folder_type_bower_opened.svg",
+	"buildkite":               "folder_type_buildkite.svg",
+	"buildkite_opened":        "folder_type_buildkite_opened.svg",
+	"cake":                    "folder_type_cake.svg",
+	"cake_opened":             "folder_type_cake_opened.svg",
+	"certificate":             "folder_type_certificate.svg",
+	"certificate_opened":      "folder_type_certificate_opened.svg",
+	"chef":                    "folder_type_chef.svg",
+	"chef_opened":             "folder_type_chef_opened.svg",
+	"circleci":                "folder_type_circleci.svg",
+	"circleci_opened":         "folder_type_circleci_opened.svg",
+	"cli":                     "folder_type_cli.svg",
+	"cli_opened":              "folder_type_cli_opened.svg",
+	"client":                  "folder_type_client.svg",
+	"client_opened":           "folder_type_client_opened.svg",
+	"cmake":                   "folder_type_cmake.svg",
+	"cmake_opened":            "folder_type_cmake_opened.svg",
+	"component":               "folder_type_component.svg",
+	"component_opened":        "folder_type_component_opened.svg",
+	"composer":                "folder_type_composer.svg",
+	"composer_opened":         "folder_type_composer_opened.svg",
+	"config":                  "folder_type_config.svg",
+	"config_opened":           "folder_type_config_opened.svg",
+	"controller":              "folder_type_controller.svg",
+	"controller_opened":       "folder_type_controller_opened.svg",
+	"coverage":                "folder_type_coverage.svg",
+	"coverage_opened":         "folder_type_coverage_opened.svg",
+	"css":                     "folder_type_css.svg",
+	"css_opened":              "folder_type_css_opened.svg",
+	"cubit":                   "folder_type_cubit.svg",
+	"cubit_opened":            "folder_type_cubit_opened.svg",
+	"cypress":                 "folder_type_cypress.svg",
+	"cypress_opened":          "folder_type_cypress_opened.svg",
+	"dapr":                    "folder_type_dapr.svg",
+	"dapr_opened":             "folder_type_dapr_opened.svg",
+	"db":                      "folder_type_db.svg",
+	"db_opened":               "folder_type_db_opened.svg",
+	"debian":                  "folder_type_debian.svg",
+	"debian_opened":           "folder_type_debian_opened.svg",
+	"dependabot":              "folder_type_dependabot.svg",
+	"dependabot_opened":       "folder_type_dependabot_opened.svg",
+	"devcontainer":            "folder_type_devcontainer.svg",
+	"devcontainer_opened":     "folder_type_devcontainer_opened.svg",
+	"dist":                    "folder_type_dist.svg",
+	"dist_opened":             "folder_type_dist_opened.svg",
+	"docker":                  "folder_type_docker.svg",
+	"docker_opened":           "folder_type_docker_opened.svg",
+	"docs":                    "folder_type_docs.svg",
+	"docs_opened":             "folder_type_docs_opened.svg",
+	"e2e":                     "folder_type_e2e.svg",
+	"e2e_opened":              "folder_type_e2e_opened.svg",
+	"elasticbeanstalk":        "folder_type_elasticbeanstalk.svg",
+	"elasticbeanstalk_opened": "folder_type_elasticbeanstalk_opened.svg",
+	"electron":                "folder_type_electron.svg",
+	"electron_opened":         "folder_type_electron_opened.svg",
+	"expo":                    "folder_type_expo.svg",
+	"expo_opened":             "folder_type_expo_opened.svg",
+	"favicon":                 "folder_type_favicon.svg",
+	"favicon_opened":          "folder_type_favicon_opened.svg",
+	"flow":                    "folder_type_flow.svg",
+	"flow_opened":             "folder_type_flow_opened.svg",
+	"fonts":                   "folder_type_fonts.svg",
+	"fonts_opened":            "folder_type_fonts_opened.svg",
+	"g11n":                    "folder_type_locale.svg",
+	"gcp":                     "folder_type_gcp.svg",
+	"gcp_opened":              "folder_type_gcp_opened.svg",
+	"git":                     "folder_type_git.svg",
+	"git_opened":              "folder_type_git_opened.svg",
+	"github":                  "folder_type_github.svg",
+	"github_opened":           "folder_type_github_opened.svg",
+	"gitlab":                  "folder_type_gitlab.svg",
+	"gitlab_opened":           "folder_type_gitlab_opened.svg",
+	"globalization":           "folder_type_locale.svg",
+	"gradle":                  "folder_type_gradle.svg",
+	"gradle_opened":           "folder_type_gradle_opened.svg",
+	"graphql":                 "folder_type_graphql.svg",
+	"graphql_opened":          "folder_type_graphql_opened.svg",
+	"grunt":                   "folder_type_grunt.svg",
+	"grunt_opened":            "folder_type_grunt_opened.svg",
+	"gulp":                    "folder_type_gulp.svg",
+	"gulp_opened":             "folder_type_gulp_opened.svg",
+	"haxelib":                 "folder_type_haxelib.svg",
+	"haxelib_opened":          "folder_type_haxelib_opened.svg",
+	"helper":                  "folder_type_helper.svg",
+	"helper_opened":           "folder_type_helper_opened.svg",
+	"hook":                    "folder_type_hook.svg",
+	"hook_opened":             "folder_type_hook_opened.svg",
+	"husky":                   "folder_type_husky.svg",
+	"husky_opened":            "folder_type_husky_opened.svg",
+	"i18n":                    "folder_type_locale.svg",
+	"idea":                    "folder_type_idea.svg",
+	"idea_opened":             "folder_type_idea_opened.svg",
+	"image":                   "folder_type_images.svg",
+	"images":                  "folder_type_images.svg",
+	"images_opened":           "folder_type_images_opened.svg",
+	"img":                     "folder_type_images.svg",
+	"imgs":                    "folder_type_images.svg",
+	"include":                 "folder_type_include.svg",
+	"include_opened":          "folder_type_include_opened.svg",
+	"integration":             "folder_type_test.svg",
+	"interfaces":              "folder_type_interfaces.svg",
+	"interfaces_opened":       "folder_type_interfaces_opened.svg",
+	"internationalization":    "folder_type_locale.svg",
+	"ios":                     "folder_type_ios.svg",
+	"ios_opened":              "folder_type_ios_opened.svg",
+	"js":                      "folder_type_js.svg",
+	"js_opened":               "folder_type_js_opened.svg",
+	"json":                    "folder_type_json.svg",
+	"json_official":           "folder_type_json_official.svg",
+	"json_official_opened":    "folder_type_json_official_opened.svg",
+	"json_opened":             "folder_type_json_opened.svg",
+	"kubernetes":              "folder_type_kubernetes.svg",
+	"kubernetes_opened":       "folder_type_kubernetes_opened.svg",
+	"l10n":                    "folder_type_locale.svg",
+	"lang":                    "folder_type_locale.svg",
+	"language":                "folder_type_locale.svg",
+	"languages":               "folder_type_locale.svg",
+	"less":                    "folder_type_less.svg",
+	"less_opened":             "folder_type_less_opened.svg",
+	"library":                 "folder_type_library.svg",
+	"library_opened":          "folder_type_library_opened.svg",
+	"light_electron":          "folder_type_light_electron.svg",
+	"light_electron_opened":   "folder_type_light_electron_opened.svg",
+	"light_expo":              "folder_type_light_expo.svg",
+	"light_expo_opened":       "folder_type_light_expo_opened.svg",
+	"light_fonts":             "folder_type_light_fonts.svg",
+	"light_fonts_opened":      "folder_type_light_fonts_opened.svg",
+	"light_gradle":            "folder_type_light_gradle.svg",
+	"light_gradle_opened":     "folder_type_light_gradle_opened.svg",
+	"light_meteor":            "folder_type_light_meteor.svg",
+	"light_meteor_opened":     "folder_type_light_meteor_opened.svg",
+	"light_mysql":             "folder_type_light_mysql.svg",
+	"light_mysql_opened":      "folder_type_light_mysql_opened.svg",
+	"light_node":              "folder_type_light_node.svg",
+	"light_node_opened":       "folder_type_light_node_opened.svg",
+	"light_redux":             "folder_type_light_redux.svg",
+	"light_redux_opened":      "folder_type_light_redux_opened.svg",
+	"light_sass":              "folder_type_light_sass.svg",
+	"light_sass_opened":       "folder_type_light_sass_opened.svg",
+	"linux":                   "folder_type_linux.svg",
+	"linux_opened":            "folder_type_linux_opened.svg",
+	"locale":                  "folder_type_locale.svg",
+	"locale_opened":           "folder_type_locale_opened.svg",
+	"locales":                 "folder_type_locale.svg",
+	"localization":            "folder_type_locale.svg",
+	"log":                     "folder_type_log.svg",
+	"log_opened":              "folder_type_log_opened.svg",
+	"logs":                    "folder_type_log.svg",
+	"macos":                   "folder_type_macos.svg",
+	"macos_opened":            "folder_type_macos_opened.svg",
+	"mariadb":                 "folder_type_mariadb.svg",
+	"mariadb_opened":          "folder_type_mariadb_opened.svg",
+	"maven":                   "folder_type_maven.svg",
+	"maven_opened":            "folder_type_maven_opened.svg",
+	"memcached":               "folder_type_memcached.svg",
+	"memcached_opened":        "folder_type_memcached_opened.svg",
+	"meteor":                  "folder_type_meteor.svg",
+	"meteor_opened":           "folder_type_meteor_opened.svg",
+	"middleware":              "folder_type_middleware.svg",
+	"middleware_opened":       "folder_type_middleware_opened.svg",
+	"minikube":                "folder_type_minikube.svg",
+	"minikube_opened":         "folder_type_minikube_opened.svg",
+	"mjml":                    "folder_type_mjml.svg",
+	"mjml_opened":             "folder_type_mjml_opened.svg",
+	"mock":                    "folder_type_mock.svg",
+	"mock_opened":             "folder_type_mock_opened.svg",
+	"model":                   "folder_type_model.svg",
+	"model_opened":            "folder_type_model_opened.svg",
+	"module":                  "folder_type_module.svg",
+	"module_opened":           "folder_type_module_opened.svg",
+	"mongodb":                 "folder_type_mongodb.svg",
+	"mongodb_opened":          "folder_type_mongodb_opened.svg",
+	"mysql":                   "folder_type_mysql.svg",
+	"mysql_opened":            "folder_type_mysql_opened.svg",
+	"next":                    "folder_type_next.svg",
+	"next_opened":             "folder_type_next_opened.svg",
+	"nginx":                   "folder_type_nginx.svg",
+	"nginx_opened":            "folder_type_nginx_opened.svg",
+	"node":                    "folder_type_node.svg",
+	"node_opened":             "folder_type_node_opened.svg",
+	"notification":            "folder_type_notification.svg",
+	"notification_opened":     "folder_type_notification_opened.svg",
+	"nuget":                   "folder_type_nuget.svg",
+	"nuget_opened":            "folder_type_nuget_opened.svg",
+	"nuxt":                    "folder_type_nuxt.svg",
+	"nuxt_opened":             "folder_type_nuxt_opened.svg",
+	"package":                 "folder_type_package.svg",
+	"package_opened":          "folder_type_package_opened.svg",
+	"paket":                   "folder_type_paket.svg",
+	"paket_opened":            "folder_type_paket_opened.svg",
+	"php":                     "folder_type_php.svg",
+	"php_opened":              "folder_type_php_opened.svg",
+	"platformio":              "folder_type_platformio.svg",
+	"platformio_opened":       "folder_type_platformio_opened.svg",
+	"plugin":                  "folder_type_plugin.svg",
+	"plugin_opened":           "folder_type_plugin_opened.svg",
+	"private":                 "folder_type_private.svg",
+	"private_opened":          "folder_type_private_opened.svg",
+	"public":                  "folder_type_public.svg",
+	"public_opened":           "folder_type_public_opened.svg",
+	"python":                  "folder_type_python.svg",
+	"python_opened":           "folder_type_python_opened.svg",
+	"ravendb":                 "folder_type_ravendb.svg",
+	"ravendb_opened":          "folder_type_ravendb_opened.svg",
+	"redis":                   "folder_type_redis.svg",
+	"redis_opened":            "folder_type_redis_opened.svg",
+	"redux":                   "folder_type_redux.svg",
+	"redux_opened":            "folder_type_redux_opened.svg",
+	"route":                   "folder_type_route.svg",
+	"route_opened":            "folder_type_route_opened.svg",
+	"sass":                    "folder_type_sass.svg",
+	"sass_opened":             "folder_type_sass_opened.svg",
+	"script":                  "folder_type_script.svg",
+	"script_opened":           "folder_type_script_opened.svg",
+	"server":                  "folder_type_server.svg",
+	"server_opened":           "folder_type_server_opened.svg",
+	"services":                "folder_type_services.svg",
+	"services_opened":         "folder_type_services_opened.svg",
+	"source":                  "folder_type_src.svg",
+	"sources":                 "folder_type_src.svg",
+	"spec":                    "folder_type_test.svg",
+	"specs":                   "folder_type_test.svg",
+	"src":                     "folder_type_src.svg",
+	"src_opened":              "folder_type_src_opened.svg",
+	"sso":                     "folder_type_sso.svg",
+	"sso_opened":              "folder_type_sso_opened.svg",
+	"story":                   "folder_type_story.svg",
+	"story_opened":            "folder_type_story_opened.svg",
+	"style":                   "folder_type_style.svg",
+	"style_opened":            "folder_type_style_opened.svg",
+	"temp":                    "folder_type_temp.svg",
+	"temp_opened":             "folder_type_temp_opened.svg",
+	"template":                "folder_type_template.svg",
+	"template_opened":         "folder_type_template_opened.svg",
+	"test":                    "folder_type_test.svg",
+	"test_opened":             "folder_type_test_opened.svg",
+	"theme":                   "folder_type_theme.svg",
+	"theme_opened":            "folder_type_theme_opened.svg",
+	"tools":                   "folder_type_tools.svg",
+	"tools_opened":            "folder_type_tools_opened.svg",
+	"travis":                  "folder_type_travis.svg",
+	"travis_opened":           "folder_type_travis_opened.svg",
+	"typescript":              "folder_type_typescript.svg",
+	"typescript_opened":       "folder_type_typescript_opened.svg",
+	"typings":                 "folder_type_typings.svg",
+	"typings2":                "folder_type_typings2.svg",
+	"typings2_opened":         "folder_type_typings2_opened.svg",
+	"typings_opened":          "folder_type_typings_opened.svg",
+	"vagrant":                 "folder_type_vagrant.svg",
+	"vagrant_opened":          "folder_type_vagrant_opened.svg",
+	"video":                   "folder_type_video.svg",
+	"video_opened":            "folder_type_video_opened.svg",
+	"view":                    "folder_type_view.svg",
+	"view_opened":             "folder_type_view_opened.svg",
+	"vs":                      "folder_type_vs.svg",
+	"vs2":                     "folder_type_vs2.svg",
+	"vs2_opened":              "folder_type_vs2_opened.svg",
+	"vs_opened":               "folder_type_vs_opened.svg",
+	"vscode":                  "folder_type_vscode.svg",
+	"vscode2":                 "folder_type_vscode2.svg",
+	"vscode2_opened":          "folder_type_vscode2_opened.svg",
+	"vscode3":                 "folder_type_vscode3.svg",
+	"vscode3_opened":          "folder_type_vscode3_opened.svg",
+	"vscode_opened":           "folder_type_vscode_opened.svg",
+	"vscode_test":             "folder_type_vscode_test.svg",
+	"vscode_test2":            "folder_type_vscode_test2.svg",
+	"vscode_test2_opened":     "folder_type_vscode_test2_opened.svg",
+	"vscode_test3":            "folder_type_vscode_test3.svg",
+	"vscode_test3_opened":     "folder_type_vscode_test3_opened.svg",
+	"vscode_test_opened":      "folder_type_vscode_test_opened.svg",
+	"webpack":                 "folder_type_webpack.svg",
+	"webpack_opened":          "folder_type_webpack_opened.svg",
+	"win":                     "folder_type_windows.svg",
+	"windows":                 "folder_type_windows.svg",
+	"windows_opened":          "folder_type_windows_opened.svg",
+	"www":                     "folder_type_www.svg",
+	"www_opened":              "folder_type_www_opened.svg",
+	"yarn":                    "folder_type_yarn.svg",
+	"yarn_opened":             "folder_type_yarn_opened.svg",
+}
@@ -0,0 +1,36 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIconsExist(t *testing.T) {
+	for name, icons := range map[string]map[string]string{"fileIcons": fileIcons, "folderIcons": folderIcons} {
+		for key, icon := range icons {
+			if _, err := os.Stat(filepath.Join("..", "..", "static", "icons", icon)); err != nil {
+				t.Errorf("%s[%q]: %v", name, key, err)
+			}
+		}
+	}
+}
+
+func TestGetExtensionsIcon(t *testing.T) {
+	tests := []struct {
+		ext   string
+		isDir bool
+		want  string
+	}{
+		{".md", false, iconsPath + "file_type_markdown.svg"},
+		{".MD", false, iconsPath + "file_type_markdown.svg"},
+		{".unknown", false, iconsPath + "default_file.svg"},
+		{"test", true, iconsPath + "folder_type_test.svg"},
+		{"unknown", true, iconsPath + "default_folder.svg"},
+	}
+	for _, tt := range tests {
+		if got := GetExtensionsIcon(tt.ext, tt.isDir); got != tt.want {
+			t.Errorf("GetExtensionsIcon(%q, %v) = %q, want %q", tt.ext, tt.isDir, got, tt.want)
+		}
+	}
+}
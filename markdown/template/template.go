@@ -95,10 +95,285 @@ footnote * {
 
 
 func Execute(wr io.Writer, tmplStr string,data *convert.TemplateData) error {
-	// 解析模板
-	tmpl, err := textTemplate.New("markdown").Parse(tmplStr); 
+	// 解析模板, sprig函数中不包含读取环境变量的函数
+	tmpl, err := textTemplate.New("markdown").Funcs(convert.TemplateFuncs()).Parse(tmplStr)
 	if err != nil {
 		return err
 	}
 	return tmpl.Execute(wr, data)
-}
\ No newline at end of file
+}
+// Slides 幻灯片模式(?slides或元数据layout: slides)的模板, 每个 section.slide 为一页
+var Slides = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width,initial-scale=1">
+<title>{{.Title | html}}</title>
+<link rel="stylesheet" href="/static/css/katex/0.16.11/katex.min.css">
+<script defer src="/static/js/katex/0.16.11/katex.min.js"></script>
+<script defer src="/static/js/katex/0.16.11/auto-render.min.js"></script>
+<style>
+html, body {
+	margin:0;
+	height:100%;
+	overflow:hidden;
+	background-color:#222;
+}
+body > * {
+	display:none;
+}
+body > section.slide, body > .slides-progress {
+	display:block;
+}
+section.slide {
+	position:absolute;
+	top:0;
+	left:0;
+	right:0;
+	bottom:0;
+	box-sizing:border-box;
+	margin:2vh auto;
+	width:96vw;
+	max-width:160vh;
+	padding:4vh 5vw;
+	overflow:auto;
+	background-color:#fff;
+	font-size:3.2vh;
+	line-height:1.5em;
+	/* 隐藏的页面保留布局, mermaid等可以计算尺寸 */
+	visibility:hidden;
+}
+section.slide.current {
+	visibility:visible;
+}
+section.slide h1, section.slide h2 {
+	margin-top:0;
+}
+section.slide img {
+	max-width:100%;
+	max-height:70vh;
+}
+section.slide table {
+	border-collapse:collapse;
+}
+section.slide th, section.slide td {
+	border:1px solid gray;
+	padding:0.2em 0.5em;
+}
+section.slide pre {
+	overflow-x:auto;
+	font-size:0.8em;
+}
+section.slide aside.notes {
+	display:none;
+	margin-top:2em;
+	padding:0.5em 1em;
+	border-left:4px solid #f0ad4e;
+	background-color:#fcf8e3;
+	font-size:0.7em;
+}
+body.show-notes section.slide aside.notes {
+	display:block;
+}
+.slides-progress {
+	position:fixed;
+	right:1em;
+	bottom:0.5em;
+	color:#999;
+	font:12px sans-serif;
+}
+</style>
+</head>
+<body>
+{{.MdHtml}}
+<div class="slides-progress"></div>
+<script>
+document.addEventListener("DOMContentLoaded", function () {
+	var slides = document.querySelectorAll("body > section.slide");
+	var progress = document.querySelector(".slides-progress");
+	var current = 0;
+	function show(n) {
+		if (!slides.length) {
+			return;
+		}
+		n = Math.max(0, Math.min(slides.length - 1, n));
+		slides[current].classList.remove("current");
+		current = n;
+		slides[current].classList.add("current");
+		progress.textContent = (current + 1) + " / " + slides.length;
+		history.replaceState(null, "", "#" + (current + 1));
+	}
+	function fromHash() {
+		var n = parseInt(location.hash.slice(1), 10);
+		return isNaN(n) ? 0 : n - 1;
+	}
+	if (window.renderMathInElement) {
+		renderMathInElement(document.body, {
+			strict: "ignore",
+			throwOnError: false
+		});
+	}
+	show(fromHash());
+	window.addEventListener("hashchange", function () {
+		show(fromHash());
+	});
+	document.addEventListener("keydown", function (e) {
+		if (e.ctrlKey || e.metaKey || e.altKey) {
+			return;
+		}
+		switch (e.key) {
+		case "ArrowRight": case "ArrowDown": case "PageDown": case " ":
+			show(current + 1);
+			break;
+		case "ArrowLeft": case "ArrowUp": case "PageUp":
+			show(current - 1);
+			break;
+		case "Home":
+			show(0);
+			break;
+		case "End":
+			show(slides.length - 1);
+			break;
+		case "s":
+			document.body.classList.toggle("show-notes");
+			break;
+		case "f":
+			if (document.fullscreenElement) {
+				document.exitFullscreen();
+			} else {
+				document.documentElement.requestFullscreen();
+			}
+			break;
+		case "Escape":
+			if (document.fullscreenElement) {
+				return;
+			}
+			var url = new URL(location.href);
+			url.searchParams.delete("slides");
+			url.hash = "";
+			location.href = url.toString();
+			break;
+		default:
+			return;
+		}
+		e.preventDefault();
+	});
+	document.addEventListener("click", function (e) {
+		if (e.target.closest("a, button, input, summary, .katex, aside.notes")) {
+			return;
+		}
+		show(e.clientX < window.innerWidth / 3 ? current - 1 : current + 1);
+	});
+});
+</script>
+</body>
+</html>
+`
+
+// Print 目录的打印页面(?print)的模板, 合并的目录之后每个 section.print-doc 为一个文档, 打印时每个文档从新的一页开始
+var Print = `{{define "toc"}}<ul>
+{{range .}}<li><a href="#{{.ID | html}}">{{.Title | html}}</a>{{if .Items}}{{template "toc" .Items}}{{end}}</li>
+{{end}}</ul>{{end}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width,initial-scale=1">
+<title>{{.Title | html}}</title>
+<link rel="stylesheet" href="/static/css/katex/0.16.11/katex.min.css">
+<script defer src="/static/js/katex/0.16.11/katex.min.js"></script>
+<script defer src="/static/js/katex/0.16.11/auto-render.min.js"></script>
+<style>
+@page {
+	margin:2cm 1.8cm;
+}
+body {
+	max-width:50em;
+	margin:0 auto;
+	padding:1em;
+	font-size:11pt;
+	line-height:1.6em;
+}
+h1 { font-size:1.8em; }
+h2 { font-size:1.5em; }
+h3 { font-size:1.3em; }
+h4 { font-size:1.1em; }
+h1, h2, h3, h4 {
+	break-after:avoid;
+}
+nav.print-toc ul {
+	list-style:none;
+	padding-left:1.5em;
+}
+nav.print-toc > ul {
+	padding-left:0;
+}
+nav.print-toc a {
+	color:inherit;
+	text-decoration:none;
+}
+section.print-doc {
+	break-before:page;
+	padding-top:1em;
+}
+img {
+	max-width:100%;
+}
+table {
+	border-collapse:collapse;
+}
+th, td {
+	border:1px solid gray;
+	padding:0.2em 0.5em;
+}
+pre, table, img, figure, blockquote {
+	break-inside:avoid;
+}
+code {
+	background-color:#f0f0f0;
+	padding:0.1em 0.2em;
+	border-radius:0.2em;
+}
+pre {
+	padding:0.8em 0.5em;
+	background-color:#f6f8fa;
+	white-space:pre-wrap;
+	word-break:break-all;
+	font-size:10pt;
+}
+pre code {
+	padding:0;
+	background-color:transparent;
+}
+.print-more {
+	color:gray;
+}
+@media print {
+	a {
+		color:inherit;
+		text-decoration:none;
+	}
+	.footnote-backref {
+		display:none;
+	}
+}
+</style>
+</head>
+<body>
+<h1>{{.Title | html}}</h1>
+<nav class="print-toc">
+{{template "toc" .Toc}}
+</nav>
+{{.MdHtml}}
+<script>
+document.addEventListener("DOMContentLoaded", function () {
+	if (window.renderMathInElement) {
+		renderMathInElement(document.body, {
+			strict: "ignore",
+			throwOnError: false
+		});
+	}
+});
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,43 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+func TestExecuteEscapesTitle(t *testing.T) {
+	data := &convert.TemplateData{
+		Title: `</title><script>alert(1)</script>`,
+		Toc:   []convert.TocItem{{Title: "<b>", ID: `a"b`}},
+	}
+	tests := []struct {
+		name string
+		tmpl string
+	}{
+		{"slides", Slides},
+		{"print", Print},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := Execute(&b, tt.tmpl, data); err != nil {
+				t.Fatal(err)
+			}
+			out := b.String()
+			for _, s := range []string{"<script>alert", "<b>", `a"b`} {
+				if strings.Contains(out, s) {
+					t.Errorf("%q is not escaped", s)
+				}
+			}
+			// katex 使用本地的静态文件, 不依赖 CDN
+			if strings.Contains(out, "cdn.jsdelivr.net") {
+				t.Errorf("uses the CDN")
+			}
+			if !strings.Contains(out, "/static/js/katex/0.16.11/katex.min.js") {
+				t.Errorf("katex.min.js is not loaded from /static")
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package markdown
+
+import (
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+// NavTree 全站的导航树(TemplateData.Tree). 目录中的 _sidebar.md 代替自动生成的该目录的子树.
+type NavTree struct {
+	// 最多显示的层数, 0表示不限制. 当前页面所在路径上的目录总是展开
+	Depth int `json:"depth,omitempty"`
+}
+
+// sidebarFileName docsify风格的侧边栏文件
+const sidebarFileName = "_sidebar.md"
+
+// 缓存的导航树最多每隔treeCheckInterval检查一次文件是否修改
+const treeCheckInterval = 2 * time.Second
+
+// navNode 缓存的导航树节点, 没有按用户的权限过滤
+type navNode struct {
+	title    string
+	href     string // 站点内的链接(不带版本前缀)或者外部链接
+	isDir    bool
+	page     bool     // 是否文档(markdown文件或者有index文件的目录), 用于上一篇/下一篇
+	local    bool     // 站点内的链接, 需要检查权限
+	access   []string // markdown元数据中的access
+	children []*navNode
+}
+
+// fileStamp 生成导航树时读取的目录和文件
+type fileStamp struct {
+	name    string
+	modTime time.Time
+	size    int64
+}
+
+// siteTree 缓存的导航树, 读取过的目录或文件修改后重新生成
+type siteTree struct {
+	home    *navNode // 根目录的index文件
+	nodes   []*navNode
+	stamps  []fileStamp
+	checked atomic.Int64 // 上次检查文件的时间(UnixNano)
+}
+
+type treeKey struct {
+	fsys fs.FS
+	root string
+	lang string
+}
+
+// getSiteTree 返回缓存的导航树, 文件修改后重新生成
+func (md *Markdown) getSiteTree(fsys fs.FS, root, lang string) *siteTree {
+	key := treeKey{fsys: fsys, root: root, lang: lang}
+	if v, ok := md.treeCache.Load(key); ok {
+		tree := v.(*siteTree)
+		now := time.Now().UnixNano()
+		last := tree.checked.Load()
+		// 其它请求正在检查时使用旧的导航树
+		if now-last < int64(treeCheckInterval) || !tree.checked.CompareAndSwap(last, now) || !tree.modified(fsys) {
+			return tree
+		}
+	}
+	b := &treeBuilder{md: md, fsys: fsys, root: root, lang: lang}
+	tree := &siteTree{}
+	if index, ok := md.findIndex(fsys, root); ok {
+		tree.home = &navNode{href: "/", isDir: true, page: true, local: true}
+		tree.home.title, tree.home.access = b.index(index, "Home")
+	}
+	tree.nodes = b.dir(root, 0)
+	tree.stamps = b.stamps
+	tree.checked.Store(time.Now().UnixNano())
+	md.treeCache.Store(key, tree)
+	return tree
+}
+
+// modified 读取过的目录或文件是否有修改(目录中增加、删除文件时目录的修改时间会变化)
+func (t *siteTree) modified(fsys fs.FS) bool {
+	for _, s := range t.stamps {
+		info, err := fs.Stat(fsys, s.name)
+		if err != nil || !info.ModTime().Equal(s.modTime) || (!info.IsDir() && info.Size() != s.size) {
+			return true
+		}
+	}
+	return false
+}
+
+// treeBuilder 生成导航树, 记录读取过的目录和文件
+type treeBuilder struct {
+	md     *Markdown
+	fsys   fs.FS
+	root   string
+	lang   string
+	stamps []fileStamp
+}
+
+func (b *treeBuilder) stamp(name string) (fs.FileInfo, bool) {
+	info, err := fs.Stat(b.fsys, name)
+	if err != nil {
+		return nil, false
+	}
+	b.stamps = append(b.stamps, fileStamp{name: name, modTime: info.ModTime(), size: info.Size()})
+	return info, true
+}
+
+// index 目录的标题(index文件元数据中的title, 没有时为目录名)和index文件的access
+func (b *treeBuilder) index(index, dirName string) (string, []string) {
+	info, ok := b.stamp(index)
+	if !ok {
+		return dirName, nil
+	}
+	meta := b.md.getDocMeta(b.fsys, index, info)
+	return pageTitle(meta.title, dirName), meta.access
+}
+
+// dir 按阅读顺序生成目录的子树, 有 _sidebar.md 时使用 _sidebar.md
+func (b *treeBuilder) dir(dir string, depth int) []*navNode {
+	if depth > maxWalkDepth {
+		return nil
+	}
+	b.stamp(dir)
+	if info, ok := b.stamp(path.Join(dir, sidebarFileName)); ok && !info.IsDir() {
+		return b.sidebar(dir)
+	}
+	b.stamp(path.Join(dir, orderFileName))
+	items, access := b.md.readItems(b.fsys, b.root, dir, b.lang)
+	b.md.setDirWeights(b.fsys, dir, items)
+	b.md.orderItems(b.fsys, dir, items)
+	index, hasIndex := b.md.findIndex(b.fsys, dir)
+	var nodes []*navNode
+	for _, item := range items {
+		filename := path.Join(dir, item.Name)
+		if item.IsFile {
+			if isMarkdownFile(item.Name) && !(hasIndex && filename == index) {
+				b.stamp(filename)
+				nodes = append(nodes, &navNode{title: pageTitle(item.Title, item.Name), href: item.Href, page: true, local: true, access: access[item.Name]})
+			}
+			continue
+		}
+		node := &navNode{title: item.Name, href: item.Href, isDir: true, local: true}
+		if sub, ok := b.md.findIndex(b.fsys, filename); ok {
+			node.title, node.access = b.index(sub, item.Name)
+			node.page = true
+		}
+		node.children = b.dir(filename, depth+1)
+		// 没有文档的目录(例如图片目录)不显示
+		if node.page || len(node.children) > 0 {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// sidebar 使用目录中的 _sidebar.md 生成子树, 相对链接从该目录开始, 可以省略 .md
+func (b *treeBuilder) sidebar(dir string) []*navNode {
+	content, err := fs.ReadFile(b.fsys, path.Join(dir, sidebarFileName))
+	if err != nil {
+		return nil
+	}
+	var urlDir string
+	if b.root == "." {
+		urlDir = "/" + strings.TrimPrefix(dir, ".")
+	} else {
+		urlDir = "/" + strings.TrimPrefix(strings.TrimPrefix(dir, b.root), "/")
+	}
+	return b.sidebarNodes(urlDir, convert.ParseSidebar(content))
+}
+
+func (b *treeBuilder) sidebarNodes(urlDir string, items []convert.SidebarItem) []*navNode {
+	var nodes []*navNode
+	for _, item := range items {
+		node := &navNode{title: item.Title, href: item.Link}
+		if u, err := url.Parse(item.Link); err == nil && item.Link != "" && u.Scheme == "" && u.Host == "" {
+			b.resolveLink(node, urlDir, item.Link)
+		}
+		node.children = b.sidebarNodes(urlDir, item.Items)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// resolveLink 把 _sidebar.md 中站点内的链接转换为从根目录开始的链接, 并读取文档的标题和access
+func (b *treeBuilder) resolveLink(node *navNode, urlDir, link string) {
+	node.local = true
+	target, suffix := link, ""
+	if i := strings.IndexAny(link, "?#"); i >= 0 {
+		target, suffix = link[:i], link[i:]
+	}
+	if target == "" {
+		// 只有锚点, 链接到 _sidebar.md 所在的目录
+		target = urlDir + "/"
+	}
+	if !strings.HasPrefix(target, "/") {
+		trailing := strings.HasSuffix(target, "/")
+		target = path.Join(urlDir, target)
+		if trailing && target != "/" {
+			target += "/"
+		}
+	}
+	filename := strings.TrimSuffix(caddyhttp.SanitizedPathJoin(b.root, target), "/")
+	info, ok := b.stamp(filename)
+	if !ok && path.Ext(target) == "" {
+		if info, ok = b.stamp(filename + ".md"); ok {
+			target, filename = target+".md", filename+".md"
+		}
+	}
+	switch {
+	case ok && info.IsDir():
+		node.isDir = true
+		target = strings.TrimSuffix(target, "/") + "/"
+		if index, found := b.md.findIndex(b.fsys, filename); found {
+			title, access := b.index(index, path.Base(filename))
+			if node.title == "" {
+				node.title = title
+			}
+			node.access = access
+			node.page = true
+		}
+	case ok && isMarkdownFile(filename):
+		meta := b.md.getDocMeta(b.fsys, filename, info)
+		node.page, node.access = true, meta.access
+		if node.title == "" {
+			node.title = pageTitle(meta.title, path.Base(filename))
+		}
+	}
+	node.href = target + suffix
+	if node.title == "" {
+		node.title = pageTitle("", path.Base(target))
+	}
+}
+
+// currentHrefs 当前页面的链接: 目录、目录的index文件都对应目录; 语言版本对应默认语言的文件
+func (md *Markdown) currentHrefs(r *http.Request, fsys fs.FS, data *convert.TemplateData, isDir bool) map[string]bool {
+	if isDir {
+		return map[string]bool{strings.TrimSuffix(r.URL.Path, "/") + "/": true}
+	}
+	current := map[string]bool{r.URL.Path: true}
+	dir, name := path.Split(r.URL.Path)
+	base, lang := md.splitLanguage(name)
+	if lang != "" {
+		current[dir+base] = true
+	}
+	if index, ok := md.findIndex(fsys, path.Dir(data.CurrentFile)); ok && (path.Base(index) == name || path.Base(index) == base) {
+		current[dir] = true
+	}
+	return current
+}
+
+// setTree 设置当前用户可见的导航树, 以及按阅读顺序(导航树深度优先)的上一篇和下一篇文档
+func (md *Markdown) setTree(r *http.Request, fsys fs.FS, data *convert.TemplateData, root string, isDir bool) {
+	if md.Tree == nil && !data.CurrentIsFile {
+		return
+	}
+	tree := md.getSiteTree(fsys, root, languageFromRequest(r))
+	current := md.currentHrefs(r, fsys, data, isDir)
+	if md.Tree != nil {
+		data.Tree, _ = md.treeNodes(r, tree.nodes, current, 1)
+	}
+	if !data.CurrentIsFile {
+		return
+	}
+	pages := md.treePages(r, tree)
+	for i, page := range pages {
+		if !current[page.Href] {
+			continue
+		}
+		if i > 0 {
+			data.Prev = &pages[i-1]
+		}
+		if i+1 < len(pages) {
+			data.Next = &pages[i+1]
+		}
+		return
+	}
+}
+
+// treeNodes 按用户的权限过滤导航树, 标记当前页面并展开当前页面所在的路径
+func (md *Markdown) treeNodes(r *http.Request, nodes []*navNode, current map[string]bool, level int) (items []convert.TreeNode, expanded bool) {
+	for _, n := range nodes {
+		if n.local && !md.canView(r, n.href, n.access) {
+			continue
+		}
+		item := convert.TreeNode{Title: n.title, Href: n.href, IsDir: n.isDir, Current: n.href != "" && current[n.href]}
+		children, onPath := md.treeNodes(r, n.children, current, level+1)
+		item.Expanded = onPath || (item.Current && len(children) > 0)
+		if md.Tree.Depth <= 0 || level < md.Tree.Depth || item.Expanded {
+			item.Children = children
+		}
+		expanded = expanded || item.Current || onPath
+		items = append(items, item)
+	}
+	return items, expanded
+}
+
+// treePages 深度优先遍历导航树中当前用户可见的文档, 根目录的index文件排在最前面
+func (md *Markdown) treePages(r *http.Request, tree *siteTree) []convert.PageLink {
+	var pages []convert.PageLink
+	seen := map[string]bool{}
+	add := func(n *navNode) {
+		if n.page && !seen[n.href] {
+			seen[n.href] = true
+			pages = append(pages, convert.PageLink{Title: n.title, Href: n.href})
+		}
+	}
+	var walk func(nodes []*navNode)
+	walk = func(nodes []*navNode) {
+		for _, n := range nodes {
+			if n.local && !md.canView(r, n.href, n.access) {
+				continue
+			}
+			add(n)
+			walk(n.children)
+		}
+	}
+	if tree.home != nil && md.canView(r, tree.home.href, tree.home.access) {
+		add(tree.home)
+	}
+	walk(tree.nodes)
+	return pages
+}
+
+// pageFile 导航树中文档的链接对应的文件: 目录为其index文件, 有语言lang的版本时使用该版本.
+// _sidebar.md 中带参数或锚点的链接是文档的一部分, 返回false
+func (md *Markdown) pageFile(fsys fs.FS, root, href, lang string) (string, bool) {
+	if strings.ContainsAny(href, "?#") {
+		return "", false
+	}
+	filename := strings.TrimSuffix(caddyhttp.SanitizedPathJoin(root, href), "/")
+	if strings.HasSuffix(href, "/") {
+		index, ok := md.findIndex(fsys, filename)
+		if !ok {
+			return "", false
+		}
+		filename = index
+	}
+	return md.languageFile(fsys, filename, lang), true
+}
+
+// prefixTree 给导航树中站点内的链接加上版本前缀
+func prefixTree(nodes []convert.TreeNode, prefix string) {
+	for i := range nodes {
+		if strings.HasPrefix(nodes[i].Href, "/") && !strings.HasPrefix(nodes[i].Href, "//") {
+			nodes[i].Href = prefix + nodes[i].Href
+		}
+		prefixTree(nodes[i].Children, prefix)
+	}
+}
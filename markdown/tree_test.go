@@ -0,0 +1,65 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+)
+
+func treeHrefs(nodes []convert.TreeNode, hrefs map[string]bool) map[string]bool {
+	for _, n := range nodes {
+		hrefs[n.Href] = true
+		treeHrefs(n.Children, hrefs)
+	}
+	return hrefs
+}
+
+func TestTreeHidesPrivateIndex(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":        "# Home",
+		"public.md":        "# Public",
+		"team/README.md":   "---\naccess: [team]\n---\n# Team",
+		"team/plan.md":     "# Plan",
+		"book/_sidebar.md": "* [Team](/team/)\n* [Public](/public.md)\n",
+		"book/README.md":   "# Book",
+	})
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Tree = &NavTree{}
+		md.Access = &Access{}
+	})
+
+	tests := []struct {
+		name   string
+		user   string
+		groups []string
+		team   bool
+	}{
+		{"anonymous", "", nil, false},
+		{"other group", "bob", []string{"staff"}, false},
+		{"member", "ann", []string{"team"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRequest(root, "/public.md", tt.user, tt.groups...)
+			data, err := md.getTemplateData(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hrefs := treeHrefs(data.Tree, map[string]bool{})
+			if hrefs["/team/"] != tt.team {
+				t.Errorf("tree contains /team/: %v, want %v", hrefs["/team/"], tt.team)
+			}
+			if !hrefs["/public.md"] {
+				t.Error("tree should contain /public.md")
+			}
+			pages := map[string]bool{}
+			for _, p := range md.treePages(r, md.getSiteTree(md.unwrapFS(md.fsmap.Default()), root, "")) {
+				pages[p.Href] = true
+			}
+			if pages["/team/"] != tt.team || pages["/team/plan.md"] != tt.team {
+				t.Errorf("pages contain /team/: %v, want %v", pages["/team/"], tt.team)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/kingreatwill/caddy-modules/markdown/convert"
+	"github.com/kingreatwill/caddy-modules/markdown/filesystem"
+	"github.com/kingreatwill/caddy-modules/markdown/git"
+)
+
+// Versions 从git标签/分支提供多版本文档, 访问 /v/<tag-or-branch>/path
+// 时读取该版本的文件树, 不需要检出.
+type Versions struct {
+	// 仓库路径, 默认使用文件系统所在的仓库(git文件系统或者root所在的仓库)
+	Repository string `json:"repository,omitempty"`
+	// 仓库中作为文档根目录的子目录
+	Dir string `json:"dir,omitempty"`
+	// 版本的url前缀. Default: /v/
+	Prefix string `json:"prefix,omitempty"`
+	// 作为版本的标签需要匹配的模式(path.Match). Default: *
+	Pattern string `json:"pattern,omitempty"`
+	// 除了标签以外, 也可以作为版本的分支
+	Branches []string `json:"branches,omitempty"`
+	// 默认版本, 没有版本前缀的请求从该版本读取; 为空时使用当前文件系统
+	Default string `json:"default,omitempty"`
+}
+
+type ctxKey string
+
+// versionCtxKey 请求的版本信息, 见 versionInfo
+const versionCtxKey ctxKey = "markdown_version"
+
+// versionInfo 当前请求的版本
+type versionInfo struct {
+	name   string
+	fsys   fs.FS
+	prefix string // 链接的前缀, 例如 /v/v1.0.0
+}
+
+func versionFromRequest(r *http.Request) *versionInfo {
+	v, _ := r.Context().Value(versionCtxKey).(*versionInfo)
+	return v
+}
+
+// provisionVersions 设置多版本的默认值
+func (md *Markdown) provisionVersions() {
+	if md.Versions == nil {
+		return
+	}
+	if md.Versions.Prefix == "" {
+		md.Versions.Prefix = "/v/"
+	}
+	md.Versions.Prefix = "/" + strings.Trim(md.Versions.Prefix, "/") + "/"
+	if md.Versions.Pattern == "" {
+		md.Versions.Pattern = "*"
+	}
+}
+
+// versionRepository 打开版本所在的仓库
+func (md *Markdown) versionRepository(r *http.Request) (*gitv.Repository, error) {
+	repoPath := md.Versions.Repository
+	if repoPath == "" {
+		fsys, err := md.getSiteFS(r)
+		if err != nil {
+			return nil, err
+		}
+		root := md.getSiteRoot(r)
+		switch f := fsys.(type) {
+		case filesystem.RepositoryFS:
+			return f.Repository(), nil
+		case filesystem.LocalFS:
+			repoPath = f.LocalPath(root)
+		default:
+			repoPath = root
+		}
+	}
+	repo, err := md.openRepository(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening versions repository %s: %v", repoPath, err)
+	}
+	return repo, nil
+}
+
+// versionList 缓存的版本列表, 标签或者分支变化后重新排序
+type versionList struct {
+	refs  uint64 // 版本的名称和指向的提交的hash
+	names []string
+}
+
+// listVersions 列出所有版本: 匹配模式的标签(按语义化版本从新到旧排序), 然后是配置的分支.
+// 每次都读取引用, 新推送或拉取的标签立即可见; 引用没有变化时使用缓存的排序结果
+func (md *Markdown) listVersions(repo *gitv.Repository) ([]string, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	refs := fnv.New64a()
+	var names []string
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if ok, _ := path.Match(md.Versions.Pattern, name); ok {
+			names = append(names, name)
+			fmt.Fprintf(refs, "t %s %s\n", name, ref.Hash())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var branches []string
+	for _, branch := range md.Versions.Branches {
+		if ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true); err == nil {
+			branches = append(branches, branch)
+			fmt.Fprintf(refs, "b %s %s\n", branch, ref.Hash())
+		}
+	}
+	sum := refs.Sum64()
+	if v, ok := md.versionCache.Load(repo); ok && v.(*versionList).refs == sum {
+		return v.(*versionList).names, nil
+	}
+	sortVersions(names)
+	names = append(names, branches...)
+	md.versionCache.Store(repo, &versionList{refs: sum, names: names})
+	return names, nil
+}
+
+// matchVersion 返回路径rest(去掉版本前缀)开头的版本名和剩余的路径, 版本名可以包含/, 使用最长的匹配
+func matchVersion(names []string, rest string) (name, remain string, ok bool) {
+	for _, n := range names {
+		if len(n) > len(name) && (rest == n || strings.HasPrefix(rest, n+"/")) {
+			name, ok = n, true
+		}
+	}
+	if !ok {
+		return "", "", false
+	}
+	return name, strings.TrimPrefix(rest, name), true
+}
+
+// sortVersions 语义化版本从新到旧, 不是语义化版本的排在后面并按名称倒序
+func sortVersions(names []string) {
+	versions := make(map[string]*semver.Version, len(names))
+	for _, name := range names {
+		if v, err := semver.NewVersion(name); err == nil {
+			versions[name] = v
+		}
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		vi, vj := versions[names[i]], versions[names[j]]
+		switch {
+		case vi != nil && vj != nil:
+			return vi.GreaterThan(vj)
+		case vi != nil:
+			return true
+		case vj != nil:
+			return false
+		}
+		return names[i] > names[j]
+	})
+}
+
+// versionFS 返回版本的文件树, 版本不存在时返回 fs.ErrNotExist
+func (md *Markdown) versionFS(repo *gitv.Repository, name string) (fs.FS, error) {
+	names, err := md.listVersions(repo)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, n := range names {
+		found = found || n == name
+	}
+	if !found {
+		return nil, fs.ErrNotExist
+	}
+	var ref string
+	if _, err := repo.Tag(name); err == nil {
+		ref = plumbing.NewTagReferenceName(name).String()
+	} else {
+		ref = plumbing.NewBranchReferenceName(name).String()
+	}
+	var fsys fs.FS = git.NewTreeFS(repo, ref)
+	if dir := path.Clean("/" + md.Versions.Dir)[1:]; dir != "" {
+		return fs.Sub(fsys, dir)
+	}
+	return fsys, nil
+}
+
+// withVersion 如果请求的是某个版本, 返回去掉版本前缀并带有版本信息的请求
+func (md *Markdown) withVersion(r *http.Request) (*http.Request, error) {
+	if md.Versions == nil {
+		return r, nil
+	}
+	if !strings.HasPrefix(r.URL.Path, md.Versions.Prefix) && md.Versions.Default == "" {
+		return r, nil
+	}
+	repo, err := md.versionRepository(r)
+	if err != nil {
+		return nil, caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+
+	name, rest, prefix := md.Versions.Default, r.URL.Path, ""
+	if strings.HasPrefix(r.URL.Path, md.Versions.Prefix) {
+		names, err := md.listVersions(repo)
+		if err != nil {
+			return nil, caddyhttp.Error(http.StatusInternalServerError, err)
+		}
+		versioned := strings.TrimPrefix(r.URL.Path, md.Versions.Prefix)
+		var ok bool
+		if name, rest, ok = matchVersion(names, versioned); !ok {
+			name, _, _ = strings.Cut(versioned, "/")
+			return nil, caddyhttp.Error(http.StatusNotFound, fmt.Errorf("unknown version: %s", name))
+		}
+		rest = "/" + strings.TrimPrefix(rest, "/")
+		prefix = md.Versions.Prefix + name
+	}
+	fsys, err := md.versionFS(repo, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, caddyhttp.Error(http.StatusNotFound, fmt.Errorf("unknown version: %s", name))
+	}
+	if err != nil {
+		return nil, caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+
+	u := *r.URL
+	u.Path = rest
+	u.RawPath = ""
+	ctx := context.WithValue(r.Context(), versionCtxKey, &versionInfo{name: name, fsys: fsys, prefix: prefix})
+	r = r.WithContext(ctx)
+	r.URL = &u
+	return r, nil
+}
+
+// serveVersion 直接从版本的文件树中返回文件, 不经过后续的handler
+func (md *Markdown) serveVersion(w http.ResponseWriter, r *http.Request, format string, next caddyhttp.Handler) error {
+	v := versionFromRequest(r)
+	filename := strings.TrimSuffix(caddyhttp.SanitizedPathJoin(".", r.URL.Path), "/")
+	info, err := fs.Stat(v.fsys, filename)
+	if err != nil {
+		if v.prefix == "" {
+			// 默认版本中没有的文件交给后续的handler(例如 /static)
+			return next.ServeHTTP(w, r)
+		}
+		return caddyhttp.Error(http.StatusNotFound, err)
+	}
+	if info.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			target := v.prefix + r.URL.Path + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return nil
+		}
+		data, err := md.getTemplateData(r)
+		if err != nil {
+			return statusError(err)
+		}
+		if !data.CurrentIsFile {
+			if format == formatJSON {
+				return md.writeJSON(w, r, http.StatusOK, data, "")
+			}
+			data.Title = path.Base(r.URL.Path)
+			return md.writeHTML(w, r, http.StatusOK, data, "")
+		}
+		filename = data.CurrentFile
+	} else {
+		filename = md.languageFile(v.fsys, filename, languageFromRequest(r))
+	}
+
+	file, err := v.fsys.Open(filename)
+	if err != nil {
+		return caddyhttp.Error(http.StatusNotFound, err)
+	}
+	defer file.Close()
+	if md.isTableFile(filename) && format != formatMarkdown {
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return statusError(err)
+		}
+		table, err := md.tableMarkdown(filename, content)
+		if err != nil {
+			return caddyhttp.Error(http.StatusUnprocessableEntity, err)
+		}
+		return md.serveMarkdown(w, r, http.StatusOK, table, format)
+	}
+	if !isMarkdownFile(filename) {
+		if ctype := mime.TypeByExtension(path.Ext(filename)); ctype != "" {
+			w.Header().Set("Content-Type", ctype)
+		}
+		if rs, ok := file.(io.ReadSeeker); ok {
+			http.ServeContent(w, r, info.Name(), info.ModTime(), rs)
+			return nil
+		}
+		_, err = io.Copy(w, file)
+		return err
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return statusError(err)
+	}
+	return md.serveMarkdown(w, r, http.StatusOK, string(content), format)
+}
+
+// setVersions 填充版本切换列表, 链接保持当前页面的路径
+func (md *Markdown) setVersions(r *http.Request, data *convert.TemplateData) {
+	if md.Versions == nil {
+		return
+	}
+	repo, err := md.versionRepository(r)
+	if err != nil {
+		return
+	}
+	names, err := md.listVersions(repo)
+	if err != nil {
+		return
+	}
+	current := md.Versions.Default
+	if v := versionFromRequest(r); v != nil {
+		current = v.name
+	}
+	data.Version = current
+	for _, name := range names {
+		data.Versions = append(data.Versions, convert.VersionItem{
+			Name:    name,
+			Href:    md.Versions.Prefix + name + r.URL.Path,
+			Current: name == current,
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package markdown
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	gitv "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestSortVersions(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want []string
+	}{
+		{[]string{"v1.0.0", "v1.10.0", "v1.2.0"}, []string{"v1.10.0", "v1.2.0", "v1.0.0"}},
+		{[]string{"v2.0.0-rc.1", "v2.0.0", "v1.9.9"}, []string{"v2.0.0", "v2.0.0-rc.1", "v1.9.9"}},
+		{[]string{"latest", "v1.0.0", "alpha"}, []string{"v1.0.0", "latest", "alpha"}},
+		{[]string{"1.0", "v1.1"}, []string{"v1.1", "1.0"}},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		got := append([]string(nil), tt.in...)
+		sortVersions(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sortVersions(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatchVersion(t *testing.T) {
+	names := []string{"v1.0.0", "release", "release/2.x"}
+	tests := []struct {
+		rest   string
+		name   string
+		remain string
+		ok     bool
+	}{
+		{"v1.0.0/docs/a.md", "v1.0.0", "/docs/a.md", true},
+		{"v1.0.0", "v1.0.0", "", true},
+		{"release/2.x/docs/", "release/2.x", "/docs/", true},
+		{"release/docs/", "release", "/docs/", true},
+		{"release/2.xx/", "release", "/2.xx/", true},
+		{"v1.0/docs/", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		name, remain, ok := matchVersion(names, tt.rest)
+		if name != tt.name || remain != tt.remain || ok != tt.ok {
+			t.Errorf("matchVersion(%q) = %q, %q, %v, want %q, %q, %v", tt.rest, name, remain, ok, tt.name, tt.remain, tt.ok)
+		}
+	}
+}
+
+func TestServeVersion(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"README.md":      "# Home\n",
+		"docs/README.md": "# Docs\n",
+	})
+	repo, err := gitv.PlainInit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, _ := repo.Worktree()
+	if err := wt.AddGlob("."); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := wt.Commit("docs", &gitv.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateTag("v1.0.0", hash, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/release/2.x", hash)); err != nil {
+		t.Fatal(err)
+	}
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.GitIndexDir = "off"
+		md.Versions = &Versions{Branches: []string{"release/2.x"}}
+	})
+
+	tests := []struct {
+		target   string
+		status   int
+		location string
+	}{
+		{"/v/v1.0.0/docs/", http.StatusOK, ""},
+		{"/v/release/2.x/docs/", http.StatusOK, ""},
+		{"/v/release/2.x/docs?format=json", http.StatusMovedPermanently, "/v/release/2.x/docs/?format=json"},
+		{"/v/v2.0.0/docs/", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		rec := serveTest(t, md, root, newTestRequest(root, tt.target, ""))
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.target, rec.Code, tt.status)
+		}
+		if got := rec.Header().Get("Location"); got != tt.location {
+			t.Errorf("%s: Location = %q, want %q", tt.target, got, tt.location)
+		}
+	}
+}
+
+func TestListVersionsNewTag(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"README.md": "# Home\n"})
+	repo, err := gitv.PlainInit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, _ := repo.Worktree()
+	if err := wt.AddGlob("."); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := wt.Commit("docs", &gitv.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := newTestMarkdown(t, func(md *Markdown) {
+		md.Root = root
+		md.GitIndexDir = "off"
+		md.Versions = &Versions{Branches: []string{"master"}}
+	})
+
+	// 新的标签不移动HEAD, 也不需要同步
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{"", []string{"master"}},
+		{"v1.0.0", []string{"v1.0.0", "master"}},
+		{"v1.1.0", []string{"v1.1.0", "v1.0.0", "master"}},
+		{"v0.9.0", []string{"v1.1.0", "v1.0.0", "v0.9.0", "master"}},
+	}
+	for _, tt := range tests {
+		if tt.tag != "" {
+			if _, err := repo.CreateTag(tt.tag, hash, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := md.listVersions(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("after %q: listVersions() = %v, want %v", tt.tag, got, tt.want)
+		}
+	}
+	rec := serveTest(t, md, root, newTestRequest(root, "/v/v0.9.0/", ""))
+	if rec.Code != http.StatusOK {
+		t.Errorf("/v/v0.9.0/: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}